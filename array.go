@@ -0,0 +1,273 @@
+package exhaustive
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// arrayConfig is configuration for arrayChecker.
+type arrayConfig struct {
+	explicit                bool
+	checkGenerated          bool
+	ignoreConstant          *regexp.Regexp // can be nil
+	ignoreType              *regexp.Regexp // can be nil
+	reportUnnecessaryIgnore bool
+	requireDeprecated       bool
+}
+
+// arrayChecker returns a node visitor that checks for exhaustiveness of
+// enum-indexed array and slice literals for the supplied pass, and
+// reports diagnostics. The node visitor expects only *ast.CompositeLit
+// nodes.
+//
+// An array or slice literal is enum-indexed if the keys of its keyed
+// elements are of an enum type, or, if it has no keyed elements, if its
+// array length is of an enum type, as in [kindCount]string{...}.
+func arrayChecker(pass *analysis.Pass, cfg arrayConfig, generated boolCache, comments commentCache) nodeVisitor {
+	return func(n ast.Node, push bool, stack []ast.Node) (bool, string) {
+		if !push {
+			return true, resultNotPush
+		}
+
+		file := stack[0].(*ast.File)
+
+		if !cfg.checkGenerated && generated.get(file) {
+			return false, resultGeneratedFile
+		}
+
+		lit := n.(*ast.CompositeLit)
+
+		t := pass.TypesInfo.TypeOf(lit)
+		if t == nil {
+			return true, resultNotArrayLiteral
+		}
+		switch t.Underlying().(type) {
+		case *types.Array, *types.Slice:
+		default:
+			return true, resultNotArrayLiteral
+		}
+
+		if len(lit.Elts) == 0 {
+			return false, resultEmptyArrayLiteral
+		}
+
+		fileComments := comments.get(pass.Fset, file)
+		relatedComments := compositeLitComments(fileComments, stack)
+		directives, err := parseDirectives(relatedComments)
+		if err == nil {
+			err = directives.validateUse("an array or slice literal", 0)
+		}
+		if err != nil {
+			pass.Report(makeInvalidDirectiveDiagnostic(lit, err))
+		}
+
+		ignored := !cfg.explicit && directives.has(ignoreDirective)
+		if ignored && !cfg.reportUnnecessaryIgnore {
+			// Skip checking of this literal due to ignore comment.
+			// Still return true because there may be nested literals
+			// that are not to be ignored.
+			return true, resultIgnoreComment
+		}
+		if cfg.explicit && !directives.has(enforceDirective) {
+			return true, resultNoEnforceComment
+		}
+
+		if ignored {
+			if inheritsIgnore(fileComments, stack) {
+				// The check of the enclosing literal determines
+				// whether the ignore comment is necessary.
+				return true, resultIgnoreComment
+			}
+			// Check the literal, and the array and slice literals
+			// nested in it that the ignore comment applies to, without
+			// reporting, to determine whether the ignore comment is
+			// necessary.
+			var wouldReport bool
+			dryRun := func(analysis.Diagnostic) { wouldReport = true }
+			exhaustive := isExhaustiveResult(checkArrayLiteral(pass, cfg, lit, t, dryRun))
+			for _, inner := range nestedCompositeLits(lit) {
+				innerType := pass.TypesInfo.TypeOf(inner)
+				if innerType == nil || len(inner.Elts) == 0 {
+					continue
+				}
+				switch innerType.Underlying().(type) {
+				case *types.Array, *types.Slice:
+					switch result := checkArrayLiteral(pass, cfg, inner, innerType, dryRun); result {
+					case resultIndexNotEnum, resultEnumTypes:
+						// Not enum-indexed.
+					default:
+						exhaustive = exhaustive && isExhaustiveResult(result)
+					}
+				}
+			}
+			if wouldReport || !exhaustive {
+				return true, resultIgnoreComment
+			}
+			pass.Report(makeUnnecessaryIgnoreDiagnostic(pass.Fset, lit, relatedComments))
+			return true, resultUnnecessaryIgnore
+		}
+		return true, checkArrayLiteral(pass, cfg, lit, t, pass.Report)
+	}
+}
+
+// checkArrayLiteral checks the array or slice literal, of type t, for
+// exhaustiveness, reporting diagnostics using report. It returns the
+// result of the check.
+func checkArrayLiteral(pass *analysis.Pass, cfg arrayConfig, lit *ast.CompositeLit, t types.Type, report func(analysis.Diagnostic)) string {
+	indexType, keyed, ok := arrayIndexType(lit, pass.TypesInfo)
+	if !ok {
+		return resultIndexNotEnum
+	}
+
+	es, ok := composingEnumTypes(pass, indexType)
+	if !ok || len(es) == 0 {
+		return resultEnumTypes
+	}
+
+	// The length of an array, unless it is determined by the literal's
+	// elements, bounds the indexes.
+	length := int64(-1)
+	if arr, ok := t.Underlying().(*types.Array); ok && !hasEllipsisLen(lit) {
+		length = arr.Len()
+	}
+
+	var checkl checklist
+	checkl.ignoreConstant(cfg.ignoreConstant)
+	checkl.ignoreType(cfg.ignoreType)
+	checkl.requireDeprecated(cfg.requireDeprecated)
+
+	for _, e := range es {
+		checkl.add(e.typ, e.members, pass.Pkg == e.typ.Pkg())
+		// Members whose values can't be used as an index into the
+		// literal need not be listed.
+		for _, name := range e.members.Names {
+			val := e.members.NameToValue[name]
+			if i, err := strconv.ParseInt(string(val), 10, 64); err != nil || i < 0 || (length >= 0 && i >= length) {
+				checkl.found(val)
+			}
+		}
+	}
+
+	analyzeArrayLiteral(lit, pass.TypesInfo, checkl.found)
+	if len(checkl.remaining()) == 0 {
+		return resultEnumMembersAccounted
+	}
+	enumTypes := dedupEnumTypes(toEnumTypes(es))
+	report(makeArrayDiagnostic(lit, t, keyed, enumTypes, checkl.remaining()))
+	return resultReportedDiagnostic
+}
+
+// arrayIndexType returns the enum type that indexes the array or slice
+// literal: the type of the keys of the keyed elements or, if there are no
+// keyed elements, the type of the array length. The keyed return value
+// reports whether the literal has keyed elements. The ok return value is
+// false if the literal isn't indexed by a single named type.
+func arrayIndexType(lit *ast.CompositeLit, info *types.Info) (t types.Type, keyed bool, ok bool) {
+	for _, e := range lit.Elts {
+		kv, isKV := e.(*ast.KeyValueExpr)
+		if !isKV {
+			continue
+		}
+		keyed = true
+		kt, isNamed := info.TypeOf(kv.Key).(*types.Named)
+		if !isNamed {
+			continue
+		}
+		if t != nil && !types.Identical(t, kt) {
+			return nil, keyed, false
+		}
+		t = kt
+	}
+	if keyed {
+		return t, keyed, t != nil
+	}
+
+	arrayType, isArray := lit.Type.(*ast.ArrayType)
+	if !isArray || arrayType.Len == nil {
+		return nil, false, false
+	}
+	if hasEllipsisLen(lit) {
+		return nil, false, false
+	}
+	lt, isNamed := info.TypeOf(arrayType.Len).(*types.Named)
+	if !isNamed {
+		return nil, false, false
+	}
+	return lt, false, true
+}
+
+// hasEllipsisLen reports whether the literal's type is an array type of
+// the form [...]T.
+func hasEllipsisLen(lit *ast.CompositeLit) bool {
+	arrayType, ok := lit.Type.(*ast.ArrayType)
+	if !ok {
+		return false
+	}
+	_, ok = arrayType.Len.(*ast.Ellipsis)
+	return ok
+}
+
+// analyzeArrayLiteral calls each for the index of each element in the
+// array or slice literal. The index of a keyed element contributes only
+// if the key is an enum member constant, as for map keys; the index of an
+// unkeyed element is the index of the preceding element plus one.
+func analyzeArrayLiteral(lit *ast.CompositeLit, info *types.Info, each func(constantValue)) {
+	var next int64
+	for _, e := range lit.Elts {
+		kv, ok := e.(*ast.KeyValueExpr)
+		if !ok {
+			each(constantValue(strconv.FormatInt(next, 10)))
+			next++
+			continue
+		}
+		if val, ok := exprConstVal(kv.Key, info); ok {
+			each(val)
+		}
+		tv := info.Types[kv.Key]
+		if tv.Value == nil {
+			// Not a constant index; the literal doesn't type check.
+			return
+		}
+		i, exact := constant.Int64Val(constant.ToInt(tv.Value))
+		if !exact {
+			return
+		}
+		next = i + 1
+	}
+}
+
+func makeArrayDiagnostic(lit *ast.CompositeLit, t types.Type, keyed bool, enumTypes []enumType, missing map[member]struct{}) analysis.Diagnostic {
+	kind := "array"
+	if _, ok := t.Underlying().(*types.Slice); ok {
+		kind = "slice"
+	}
+	names := diagnosticGroupNames(groupify(missing, enumTypes))
+	msg := fmt.Sprintf(
+		"missing keys in %s of index type %s: %s",
+		kind,
+		diagnosticEnumTypes(enumTypes),
+		strings.Join(names, ", "),
+	)
+	if !keyed {
+		msg = fmt.Sprintf(
+			"length of unkeyed %s literal does not match index type %s: missing %s",
+			kind,
+			diagnosticEnumTypes(enumTypes),
+			strings.Join(names, ", "),
+		)
+	}
+	return analysis.Diagnostic{
+		Pos:      lit.Pos(),
+		End:      lit.End(),
+		Category: CategoryMissingElements,
+		Message:  msg,
+		Related:  missingRelated(lit, names),
+	}
+}
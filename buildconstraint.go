@@ -0,0 +1,437 @@
+package exhaustive
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// With the -build-constraints flag, enum members are also discovered in
+// the files excluded from the build, the build constraint of the file that
+// declares each enum member is recorded in the enum fact, and a switch
+// statement has to list only the members whose build constraints are
+// implied by the build constraint of the switch statement's file. For
+// example, a member declared in kind_linux.go is required only in files
+// that are built exclusively for linux.
+//
+// The build constraint of a file is the conjunction of its //go:build
+// line and the constraint implied by its name, such as the linux in
+// kind_linux.go.
+
+// Known operating systems and architectures for file name constraints,
+// from go/build.
+var (
+	knownOS = map[string]bool{
+		"aix": true, "android": true, "darwin": true, "dragonfly": true,
+		"freebsd": true, "hurd": true, "illumos": true, "ios": true,
+		"js": true, "linux": true, "nacl": true, "netbsd": true,
+		"openbsd": true, "plan9": true, "solaris": true, "wasip1": true,
+		"windows": true, "zos": true,
+	}
+	unixOS = map[string]bool{
+		"aix": true, "android": true, "darwin": true, "dragonfly": true,
+		"freebsd": true, "hurd": true, "illumos": true, "ios": true,
+		"linux": true, "netbsd": true, "openbsd": true, "solaris": true,
+	}
+	knownArch = map[string]bool{
+		"386": true, "amd64": true, "amd64p32": true, "arm": true,
+		"armbe": true, "arm64": true, "arm64be": true, "loong64": true,
+		"mips": true, "mipsle": true, "mips64": true, "mips64le": true,
+		"mips64p32": true, "mips64p32le": true, "ppc": true, "ppc64": true,
+		"ppc64le": true, "riscv": true, "riscv64": true, "s390": true,
+		"s390x": true, "sparc": true, "sparc64": true, "wasm": true,
+	}
+)
+
+// fileConstraint returns the build constraint of the file, or nil if the
+// file has none.
+func fileConstraint(fset *token.FileSet, file *ast.File) constraint.Expr {
+	var result constraint.Expr
+	and := func(x constraint.Expr) {
+		if result == nil {
+			result = x
+			return
+		}
+		result = &constraint.AndExpr{X: result, Y: x}
+	}
+
+	for _, cg := range file.Comments {
+		if cg.Pos() > file.Package {
+			break
+		}
+		for _, c := range cg.List {
+			if !constraint.IsGoBuild(c.Text) {
+				continue
+			}
+			if x, err := constraint.Parse(c.Text); err == nil {
+				and(x)
+			}
+		}
+	}
+
+	name := strings.TrimSuffix(filepath.Base(fset.File(file.Pos()).Name()), ".go")
+	name = strings.TrimSuffix(name, "_test")
+	parts := strings.Split(name, "_")
+	n := len(parts)
+	switch {
+	case n >= 3 && knownOS[parts[n-2]] && knownArch[parts[n-1]]:
+		and(&constraint.AndExpr{X: &constraint.TagExpr{Tag: parts[n-2]}, Y: &constraint.TagExpr{Tag: parts[n-1]}})
+	case n >= 2 && (knownOS[parts[n-1]] || knownArch[parts[n-1]]):
+		and(&constraint.TagExpr{Tag: parts[n-1]})
+	}
+	return result
+}
+
+// fileConstraints returns the build constraints of the files, keyed by
+// file name. Files without build constraints are omitted.
+func fileConstraints(fset *token.FileSet, files []*ast.File) map[string]constraint.Expr {
+	result := make(map[string]constraint.Expr)
+	for _, f := range files {
+		if x := fileConstraint(fset, f); x != nil {
+			result[fset.File(f.Pos()).Name()] = x
+		}
+	}
+	return result
+}
+
+// recordConstraints records, in em.Constraints, the build constraint of
+// the file that declares each member, if any.
+func (em *enumMembers) recordConstraints(fset *token.FileSet, constraints map[string]constraint.Expr) {
+	for _, name := range em.Names {
+		f := fset.File(em.NameToPos[name])
+		if f == nil {
+			continue
+		}
+		x, ok := constraints[f.Name()]
+		if !ok {
+			continue
+		}
+		if em.Constraints == nil {
+			em.Constraints = make(map[string]string)
+		}
+		em.Constraints[name] = x.String()
+	}
+}
+
+// addExcludedMembers adds to the enums the members declared in the Go
+// files of the package that are excluded from the current build
+// configuration by their build constraints, so that the members of an
+// enum type are the union of its members across build configurations.
+// The build constraint of each such member's file is recorded in
+// em.Constraints.
+//
+// The files are parsed but not type-checked, so a constant is taken to be
+// a member if its declaration names the enum type, explicitly or, in a
+// parenthesized declaration, implicitly through a preceding specification.
+// Its value is evaluated in the package scope, so a member whose value
+// refers to declarations in excluded files is not found.
+//
+// The files are parsed into a private file set, since pass.Fset is shared
+// with the driver, so the position of each such member is token.NoPos.
+func addExcludedMembers(pass *analysis.Pass, enums map[enumType]enumMembers) {
+	byName := make(map[string]enumType)
+	for typ := range enums {
+		if typ.scope() == pass.Pkg.Scope() {
+			byName[typ.Name()] = typ
+		}
+	}
+	if len(byName) == 0 {
+		return
+	}
+
+	fset := token.NewFileSet()
+	for _, name := range pass.IgnoredFiles {
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+		if err != nil || file.Name.Name != pass.Pkg.Name() {
+			continue
+		}
+		x := fileConstraint(fset, file)
+		if x == nil {
+			continue
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST || hasIgnoreComment(gen.Doc) {
+				continue
+			}
+			var typeExpr ast.Expr
+			var values []ast.Expr
+			for iota, spec := range gen.Specs {
+				spec := spec.(*ast.ValueSpec)
+				if spec.Type != nil || len(spec.Values) != 0 {
+					typeExpr, values = spec.Type, spec.Values
+				}
+				typeName, ok := typeExpr.(*ast.Ident)
+				if !ok || hasIgnoreComment(spec.Doc) {
+					continue
+				}
+				typ, ok := byName[typeName.Name]
+				if !ok {
+					continue
+				}
+				for i, id := range spec.Names {
+					if id.Name == "_" || i >= len(values) || pass.Pkg.Scope().Lookup(id.Name) != nil {
+						continue
+					}
+					members := enums[typ]
+					if _, ok := members.NameToPos[id.Name]; ok {
+						continue
+					}
+					val, ok := evalExcludedConst(pass, fset, typ, values[i], iota)
+					if !ok {
+						continue
+					}
+					members.add(id.Name, val, token.NoPos)
+					if members.Constraints == nil {
+						members.Constraints = make(map[string]string)
+					}
+					members.Constraints[id.Name] = x.String()
+					enums[typ] = members
+				}
+			}
+		}
+	}
+}
+
+// evalExcludedConst returns the value of the constant expression, of the
+// enum type, in which iota has the supplied value. The expression is from
+// a file parsed into fset.
+func evalExcludedConst(pass *analysis.Pass, fset *token.FileSet, typ enumType, expr ast.Expr, iota int) (constantValue, bool) {
+	// Print the expression with iota replaced by its value. The
+	// expression is parsed anew so as not to modify the file's syntax.
+	e, err := parser.ParseExpr(nodeString(fset, expr))
+	if err != nil {
+		return "", false
+	}
+	ast.Inspect(e, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name == "iota" {
+			id.Name = strconv.Itoa(iota)
+		}
+		return true
+	})
+	tv, err := types.Eval(fset, pass.Pkg, token.NoPos, typ.Name()+"("+nodeString(token.NewFileSet(), e)+")")
+	if err != nil || tv.Value == nil {
+		return "", false
+	}
+	return constantValue(tv.Value.ExactString()), true
+}
+
+// hasIgnoreComment reports whether the comments have an ignore directive.
+// Unlike hasIgnoreDecl, it doesn't report invalid directives.
+func hasIgnoreComment(doc *ast.CommentGroup) bool {
+	dirs, _ := parseDirectives([]*ast.CommentGroup{doc})
+	return dirs.has(ignoreDirective)
+}
+
+// restrictConstraint removes the members whose build constraints aren't
+// implied by the build constraint, which can be nil, so that they need
+// not be accounted for.
+func (c *checklist) restrictConstraint(x constraint.Expr) {
+	for m := range c.checkl {
+		s := c.info[m.typ].Constraints[m.name]
+		if s == "" {
+			continue
+		}
+		y, err := constraint.Parse("//go:build " + s)
+		if err != nil {
+			continue
+		}
+		if !implies(x, y) {
+			delete(c.checkl, m)
+		}
+	}
+}
+
+// maxImpliesSteps bounds the search of implies. If the search takes more
+// steps, implies conservatively reports false.
+const maxImpliesSteps = 1 << 14
+
+// implies reports whether x, which can be nil, implies y: whether y is
+// satisfied in each build configuration in which x is. At most one
+// operating system and one architecture are set in a build
+// configuration.
+//
+// It searches for a configuration that satisfies x but not y, assigning
+// the operating system, the architecture, and then the other tags one at
+// a time, and abandoning a partial assignment as soon as it determines x
+// to be false or y to be true.
+func implies(x, y constraint.Expr) bool {
+	if x != nil && x.String() == y.String() {
+		return true
+	}
+
+	var oses, arches, free []string
+	seen := make(map[string]bool)
+	collect := func(tag string) {
+		if seen[tag] {
+			return
+		}
+		seen[tag] = true
+		switch {
+		case knownOS[tag]:
+			oses = append(oses, tag)
+		case knownArch[tag]:
+			arches = append(arches, tag)
+		case tag != "unix":
+			free = append(free, tag)
+		}
+	}
+	walkTags(x, collect)
+	walkTags(y, collect)
+
+	// An empty operating system or architecture stands for one that
+	// isn't mentioned. Each Unix system is mentioned by the unix tag.
+	if seen["unix"] {
+		for _, goos := range sortedKeys(unixOS) {
+			if !seen[goos] {
+				oses = append(oses, goos)
+			}
+		}
+	}
+	oses = append(oses, "")
+	arches = append(arches, "")
+
+	var (
+		goos, goarch        string
+		osSet, archSet      bool
+		assigned            = make(map[string]bool) // free tag -> value
+		steps               int
+		counterexampleFound bool
+	)
+	value := func(tag string) (v, known bool) {
+		switch {
+		case knownOS[tag]:
+			return tag == goos ||
+				(goos == "android" && tag == "linux") ||
+				(goos == "ios" && tag == "darwin") ||
+				(goos == "illumos" && tag == "solaris"), osSet
+		case knownArch[tag]:
+			return tag == goarch, archSet
+		case tag == "unix":
+			return unixOS[goos], osSet
+		}
+		v, known = assigned[tag]
+		return v, known
+	}
+	// search reports whether the search is complete: whether it found a
+	// counterexample, or the step bound was exceeded.
+	var search func(i int) bool
+	search = func(i int) bool {
+		steps++
+		if steps > maxImpliesSteps {
+			return true
+		}
+		xv, xKnown := true, true
+		if x != nil {
+			xv, xKnown = eval3(x, value)
+		}
+		yv, yKnown := eval3(y, value)
+		if (xKnown && !xv) || (yKnown && yv) {
+			return false
+		}
+		if xKnown && yKnown {
+			counterexampleFound = true
+			return true
+		}
+		switch {
+		case !osSet:
+			osSet = true
+			defer func() { osSet = false }()
+			for _, goos = range oses {
+				if search(i) {
+					return true
+				}
+			}
+		case !archSet:
+			archSet = true
+			defer func() { archSet = false }()
+			for _, goarch = range arches {
+				if search(i) {
+					return true
+				}
+			}
+		case i < len(free):
+			defer delete(assigned, free[i])
+			for _, v := range []bool{false, true} {
+				assigned[free[i]] = v
+				if search(i + 1) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	search(0)
+	return !counterexampleFound && steps <= maxImpliesSteps
+}
+
+// eval3 evaluates the constraint when the values of some tags may be
+// unknown. The known return value is false if the value of the
+// constraint depends on tags whose values are unknown.
+func eval3(x constraint.Expr, value func(tag string) (v, known bool)) (v, known bool) {
+	switch x := x.(type) {
+	case *constraint.TagExpr:
+		return value(x.Tag)
+	case *constraint.NotExpr:
+		v, known := eval3(x.X, value)
+		return !v, known
+	case *constraint.AndExpr:
+		xv, xKnown := eval3(x.X, value)
+		yv, yKnown := eval3(x.Y, value)
+		switch {
+		case xKnown && !xv, yKnown && !yv:
+			return false, true
+		case xKnown && yKnown:
+			return true, true
+		}
+		return false, false
+	case *constraint.OrExpr:
+		xv, xKnown := eval3(x.X, value)
+		yv, yKnown := eval3(x.Y, value)
+		switch {
+		case xKnown && xv, yKnown && yv:
+			return true, true
+		case xKnown && yKnown:
+			return false, true
+		}
+		return false, false
+	}
+	return false, false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// walkTags calls f for each tag in the constraint, which can be nil. Eval
+// short-circuits, so it can't be relied on to visit each tag.
+func walkTags(x constraint.Expr, f func(string)) {
+	switch x := x.(type) {
+	case *constraint.TagExpr:
+		f(x.Tag)
+	case *constraint.NotExpr:
+		walkTags(x.X, f)
+	case *constraint.AndExpr:
+		walkTags(x.X, f)
+		walkTags(x.Y, f)
+	case *constraint.OrExpr:
+		walkTags(x.X, f)
+		walkTags(x.Y, f)
+	}
+}
@@ -0,0 +1,82 @@
+package exhaustive
+
+import (
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestFileConstraint(t *testing.T) {
+	tests := []struct {
+		filename string
+		src      string
+		want     string // empty if none
+	}{
+		{"kind.go", "package p", ""},
+		{"kind_linux.go", "package p", "linux"},
+		{"kind_linux_amd64.go", "package p", "linux && amd64"},
+		{"kind_arm64_test.go", "package p", "arm64"},
+		{"linux.go", "package p", ""},
+		{"kind_foo.go", "//go:build foo\n\npackage p", "foo"},
+		{"kind_windows.go", "//go:build !arm\n\npackage p", "!arm && windows"},
+		{"kind.go", "package p\n\n//go:build foo", ""},
+	}
+
+	for _, tt := range tests {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, tt.filename, tt.src, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got string
+		if x := fileConstraint(fset, file); x != nil {
+			got = x.String()
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.filename, got, tt.want)
+		}
+	}
+}
+
+func TestImplies(t *testing.T) {
+	parse := func(s string) constraint.Expr {
+		if s == "" {
+			return nil
+		}
+		x, err := constraint.Parse("//go:build " + s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return x
+	}
+
+	tests := []struct {
+		x, y string
+		want bool
+	}{
+		{"", "linux", false},
+		{"linux", "linux", true},
+		{"linux && amd64", "linux", true},
+		{"linux", "linux && amd64", false},
+		{"linux", "unix", true},
+		{"windows", "unix", false},
+		{"android", "linux", true},
+		{"linux", "!windows", true},
+		{"linux || darwin", "unix", true},
+		{"foo && bar", "foo", true},
+		{"foo", "foo || bar", true},
+		{"foo", "bar", false},
+		{"unix", "linux || darwin", false},
+		{"unix && !freebsd && !netbsd && !openbsd && !dragonfly && !solaris && !illumos && !aix && !hurd", "linux || darwin", true},
+		{"a && b && c && d && e && f && g && h && i && j && k && l && m && n && o && p", "p || q", true},
+		{"a || b || c || d || e || f || g || h || i || j || k || l || m || n || o || p", "a || b || c || d || e || f || g || h || i || j || k || l || m || n || o || p || q", true},
+		{"a || b || c || d || e || f || g || h || i || j || k || l || m || n || o || p", "q", false},
+	}
+
+	for _, tt := range tests {
+		if got := implies(parse(tt.x), parse(tt.y)); got != tt.want {
+			t.Errorf("implies(%q, %q): got %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"html/template"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nishanths/exhaustive"
+)
+
+// Values for the -format flag of the coverage subcommand.
+const (
+	coverageFormatJSON = "json"
+	coverageFormatHTML = "html"
+)
+
+var coverageFormatChoices = []string{coverageFormatJSON, coverageFormatHTML}
+
+// outcomeCounts counts the checks of an enum type by outcome.
+type outcomeCounts struct {
+	Total      int `json:"total"`
+	Exhaustive int `json:"exhaustive"`
+	Ignored    int `json:"ignored"`
+	Skipped    int `json:"skipped"`
+	Default    int `json:"default"`
+	Failing    int `json:"failing"`
+}
+
+func (c *outcomeCounts) add(o exhaustive.Outcome) {
+	c.Total++
+	switch o {
+	case exhaustive.OutcomeExhaustive:
+		c.Exhaustive++
+	case exhaustive.OutcomeIgnored:
+		c.Ignored++
+	case exhaustive.OutcomeSkipped:
+		c.Skipped++
+	case exhaustive.OutcomeDefault:
+		c.Default++
+	case exhaustive.OutcomeFailing:
+		c.Failing++
+	}
+}
+
+// suppressed returns the number of checks that were skipped or ignored.
+func (c outcomeCounts) suppressed() int {
+	return c.Ignored + c.Skipped
+}
+
+// enumCoverage is the coverage of an enum type in the output of the
+// coverage subcommand.
+type enumCoverage struct {
+	Type     string        `json:"type"` // package path and type name, such as "example.org/eco.Biome"
+	Position string        `json:"position,omitempty"`
+	Switches outcomeCounts `json:"switches"`
+	Maps     outcomeCounts `json:"maps"`
+}
+
+// Suppressed returns the number of checks of the enum type that were
+// skipped or ignored.
+func (e enumCoverage) Suppressed() int {
+	return e.Switches.suppressed() + e.Maps.suppressed()
+}
+
+func (e *enumCoverage) counts(node exhaustive.CheckNode) *outcomeCounts {
+	if node == exhaustive.CheckNodeMap {
+		return &e.Maps
+	}
+	return &e.Switches
+}
+
+// coverageReport is the output of the coverage subcommand.
+type coverageReport struct {
+	Switches outcomeCounts  `json:"switches"` // totals
+	Maps     outcomeCounts  `json:"maps"`     // totals
+	Enums    []enumCoverage `json:"enums"`
+}
+
+func (r *coverageReport) counts(node exhaustive.CheckNode) *outcomeCounts {
+	if node == exhaustive.CheckNodeMap {
+		return &r.Maps
+	}
+	return &r.Switches
+}
+
+// runCoverage implements the coverage subcommand, which reports, for each
+// enum type, the outcomes of checking the switch statements and map
+// literals that use it in the packages named in the arguments. It
+// returns the exit status.
+func runCoverage(args []string) int {
+	fs, tests := newFlagSet("exhaustive coverage")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: exhaustive coverage [flags] [packages]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	// The coverage report is of switch statements and map literals, so
+	// check both unless the -check flag says otherwise.
+	if err := fs.Set(exhaustive.CheckFlag, "switch,map"); err != nil {
+		panic(err)
+	}
+	format := fs.String("format", coverageFormatJSON, fmt.Sprintf("output format (%s)", strings.Join(coverageFormatChoices, ", ")))
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	switch *format {
+	case coverageFormatJSON, coverageFormatHTML:
+	default:
+		fmt.Fprintf(os.Stderr, "exhaustive: invalid -format %q; want one of %s\n", *format, strings.Join(coverageFormatChoices, ", "))
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	_, results, err := analyze(exhaustive.CheckAnalyzer, fs.Args(), *tests)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "exhaustive: %s\n", err)
+		return 1
+	}
+	wd, _ := os.Getwd()
+	if err := writeCoverage(os.Stdout, *format, makeCoverageReport(wd, results)); err != nil {
+		fmt.Fprintf(os.Stderr, "exhaustive: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+func writeCoverage(w io.Writer, format string, report coverageReport) error {
+	if format == coverageFormatHTML {
+		return writeCoverageHTML(w, report)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// makeCoverageReport aggregates the checks in the results of the
+// analyzer. A check in both a package and its test variant is counted
+// once.
+func makeCoverageReport(wd string, results []packageResult) coverageReport {
+	var report coverageReport
+	index := make(map[string]int) // enum type -> index in report.Enums
+	seen := make(map[string]bool) // check position
+	for _, r := range results {
+		p := r.pkg
+		for _, c := range r.result.([]exhaustive.Check) {
+			key := p.Fset.Position(c.Pos).String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			report.counts(c.Node).add(c.Outcome)
+			for _, tn := range c.Types {
+				name := enumTypeName(tn)
+				i, ok := index[name]
+				if !ok {
+					i = len(report.Enums)
+					index[name] = i
+					report.Enums = append(report.Enums, enumCoverage{Type: name})
+					if tn.Pos().IsValid() {
+						report.Enums[i].Position = formatPosition(wd, p.Fset.Position(tn.Pos()))
+					}
+				}
+				report.Enums[i].counts(c.Node).add(c.Outcome)
+			}
+		}
+	}
+	if report.Enums == nil {
+		report.Enums = []enumCoverage{}
+	}
+	sort.Slice(report.Enums, func(i, j int) bool {
+		return report.Enums[i].Type < report.Enums[j].Type
+	})
+	return report
+}
+
+func enumTypeName(tn *types.TypeName) string {
+	if tn.Pkg() == nil {
+		return tn.Name()
+	}
+	return tn.Pkg().Path() + "." + tn.Name()
+}
+
+var coverageTemplate = template.Must(template.New("coverage").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>exhaustive coverage</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+td.failing { color: #b00; }
+td.suppressed { color: #a60; }
+</style>
+</head>
+<body>
+<h1>exhaustive coverage</h1>
+<h2>Summary</h2>
+<table>
+<tr><th></th><th>Total</th><th>Exhaustive</th><th>Default</th><th>Failing</th><th>Ignored</th><th>Skipped</th></tr>
+<tr><td>Switch statements</td>{{template "counts" .Switches}}</tr>
+<tr><td>Map literals</td>{{template "counts" .Maps}}</tr>
+</table>
+<h2>Enum types</h2>
+<p>Sorted by the number of ignored or skipped switch statements and map literals.</p>
+<table>
+<tr><th rowspan="2">Enum type</th><th colspan="6">Switch statements</th><th colspan="6">Map literals</th></tr>
+<tr><th>Total</th><th>Exhaustive</th><th>Default</th><th>Failing</th><th>Ignored</th><th>Skipped</th><th>Total</th><th>Exhaustive</th><th>Default</th><th>Failing</th><th>Ignored</th><th>Skipped</th></tr>
+{{range .Enums}}<tr><td title="{{.Position}}"><code>{{.Type}}</code></td>{{template "counts" .Switches}}{{template "counts" .Maps}}</tr>
+{{end}}</table>
+</body>
+</html>
+{{define "counts"}}<td>{{.Total}}</td><td>{{.Exhaustive}}</td><td>{{.Default}}</td><td class="failing">{{.Failing}}</td><td class="suppressed">{{.Ignored}}</td><td class="suppressed">{{.Skipped}}</td>{{end}}`))
+
+func writeCoverageHTML(w io.Writer, report coverageReport) error {
+	enums := append([]enumCoverage(nil), report.Enums...)
+	sort.SliceStable(enums, func(i, j int) bool {
+		return enums[i].Suppressed() > enums[j].Suppressed()
+	})
+	report.Enums = enums
+	return coverageTemplate.Execute(w, report)
+}
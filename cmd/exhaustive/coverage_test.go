@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nishanths/exhaustive"
+)
+
+func TestWriteCoverage(t *testing.T) {
+	setAnalyzerFlags(t, map[string]string{
+		"check":                        "switch,map",
+		"default-signifies-exhaustive": "true",
+	})
+
+	_, results, err := analyze(exhaustive.CheckAnalyzer, []string{"./testdata/coverage/..."}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := makeCoverageReport(wd, results)
+
+	for _, format := range coverageFormatChoices {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeCoverage(&buf, format, report); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, filepath.Join("testdata", "coverage."+format+".golden"), buf.Bytes())
+		})
+	}
+}
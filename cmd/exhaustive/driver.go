@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+)
+
+// A finding is a diagnostic reported for a package named on the command
+// line.
+type finding struct {
+	pkg        string // package path
+	diag       analysis.Diagnostic
+	start, end token.Position
+}
+
+// A packageResult is the result of the analyzer for a package named on
+// the command line.
+type packageResult struct {
+	pkg    *packages.Package
+	result interface{}
+}
+
+// analyze loads the packages matching the patterns, along with their
+// dependencies, and applies the analyzer to each of them in dependency
+// order, so that facts flow from dependencies to dependents. It returns
+// the diagnostics reported for the packages matching the patterns,
+// sorted by position, and the results of the analyzer for those packages.
+func analyze(a *analysis.Analyzer, patterns []string, tests bool) ([]finding, []packageResult, error) {
+	if err := analysis.Validate([]*analysis.Analyzer{a}); err != nil {
+		return nil, nil, err
+	}
+
+	cfg := &packages.Config{
+		Mode:  packages.LoadAllSyntax,
+		Tests: tests,
+	}
+	initial, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if n := packages.PrintErrors(initial); n > 0 {
+		return nil, nil, fmt.Errorf("%d errors during loading", n)
+	}
+	if len(initial) == 0 {
+		return nil, nil, fmt.Errorf("%v matched no packages", patterns)
+	}
+
+	roots := make(map[*packages.Package]bool)
+	for _, p := range initial {
+		roots[p] = true
+	}
+
+	d := &driver{
+		objectFacts:  make(map[objectFactKey]analysis.Fact),
+		packageFacts: make(map[packageFactKey]analysis.Fact),
+	}
+	var findings []finding
+	var rootResults []packageResult
+	seen := make(map[string]bool) // for packages analyzed as part of their test variants too
+	var runErr error
+	packages.Visit(initial, nil, func(p *packages.Package) {
+		if runErr != nil || p.Types == nil || p.IllTyped {
+			return
+		}
+		results := make(map[*analysis.Analyzer]interface{})
+		runErr = d.run(a, p, results, func(diag analysis.Diagnostic) {
+			if !roots[p] {
+				return
+			}
+			f := finding{
+				pkg:   p.PkgPath,
+				diag:  diag,
+				start: p.Fset.Position(diag.Pos),
+				end:   p.Fset.Position(diag.End),
+			}
+			key := fmt.Sprintf("%s: %s", f.start, diag.Message)
+			if seen[key] {
+				return
+			}
+			seen[key] = true
+			findings = append(findings, f)
+		})
+		if runErr == nil && roots[p] {
+			rootResults = append(rootResults, packageResult{p, results[a]})
+		}
+	})
+	if runErr != nil {
+		return nil, nil, runErr
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		x, y := findings[i].start, findings[j].start
+		if x.Filename != y.Filename {
+			return x.Filename < y.Filename
+		}
+		if x.Line != y.Line {
+			return x.Line < y.Line
+		}
+		return x.Column < y.Column
+	})
+	return findings, rootResults, nil
+}
+
+type objectFactKey struct {
+	obj types.Object
+	typ reflect.Type
+}
+
+type packageFactKey struct {
+	pkg *types.Package
+	typ reflect.Type
+}
+
+// driver holds the facts exported by the analyzed packages.
+type driver struct {
+	objectFacts  map[objectFactKey]analysis.Fact
+	packageFacts map[packageFactKey]analysis.Fact
+}
+
+// run applies the analyzer, after the analyzers that it requires, to the
+// package, recording the results in results.
+func (d *driver) run(a *analysis.Analyzer, p *packages.Package, results map[*analysis.Analyzer]interface{}, report func(analysis.Diagnostic)) error {
+	if _, ok := results[a]; ok {
+		return nil
+	}
+	resultOf := make(map[*analysis.Analyzer]interface{})
+	for _, req := range a.Requires {
+		if err := d.run(req, p, results, func(analysis.Diagnostic) {}); err != nil {
+			return err
+		}
+		resultOf[req] = results[req]
+	}
+
+	// As in other drivers, the facts of the package and of its
+	// dependencies are visible.
+	visible := make(map[*types.Package]bool)
+	var addDeps func(p *packages.Package)
+	addDeps = func(p *packages.Package) {
+		if visible[p.Types] {
+			return
+		}
+		visible[p.Types] = true
+		for _, imp := range p.Imports {
+			addDeps(imp)
+		}
+	}
+	addDeps(p)
+
+	pass := &analysis.Pass{
+		Analyzer:     a,
+		Fset:         p.Fset,
+		Files:        p.Syntax,
+		OtherFiles:   p.OtherFiles,
+		IgnoredFiles: p.IgnoredFiles,
+		Pkg:          p.Types,
+		TypesInfo:    p.TypesInfo,
+		TypesSizes:   p.TypesSizes,
+		ResultOf:     resultOf,
+		Report:       report,
+		ImportObjectFact: func(obj types.Object, fact analysis.Fact) bool {
+			return importFact(d.objectFacts[objectFactKey{obj, reflect.TypeOf(fact)}], fact)
+		},
+		ImportPackageFact: func(pkg *types.Package, fact analysis.Fact) bool {
+			return importFact(d.packageFacts[packageFactKey{pkg, reflect.TypeOf(fact)}], fact)
+		},
+		ExportObjectFact: func(obj types.Object, fact analysis.Fact) {
+			d.objectFacts[objectFactKey{obj, reflect.TypeOf(fact)}] = fact
+		},
+		ExportPackageFact: func(fact analysis.Fact) {
+			d.packageFacts[packageFactKey{p.Types, reflect.TypeOf(fact)}] = fact
+		},
+		AllObjectFacts: func() []analysis.ObjectFact {
+			var facts []analysis.ObjectFact
+			for k, f := range d.objectFacts {
+				if visible[k.obj.Pkg()] {
+					facts = append(facts, analysis.ObjectFact{Object: k.obj, Fact: f})
+				}
+			}
+			return facts
+		},
+		AllPackageFacts: func() []analysis.PackageFact {
+			var facts []analysis.PackageFact
+			for k, f := range d.packageFacts {
+				if visible[k.pkg] {
+					facts = append(facts, analysis.PackageFact{Package: k.pkg, Fact: f})
+				}
+			}
+			return facts
+		},
+	}
+
+	result, err := a.Run(pass)
+	if err != nil {
+		return fmt.Errorf("%s: %s: %w", p.PkgPath, a.Name, err)
+	}
+	results[a] = result
+	return nil
+}
+
+// importFact copies the fact src, if it is non-nil, into dst.
+func importFact(src, dst analysis.Fact) bool {
+	if src == nil {
+		return false
+	}
+	reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(src).Elem())
+	return true
+}
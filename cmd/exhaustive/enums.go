@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nishanths/exhaustive"
+)
+
+// Values for the -format flag of the enums subcommand.
+const (
+	enumsFormatText     = "text"
+	enumsFormatJSON     = "json"
+	enumsFormatMarkdown = "markdown"
+)
+
+var enumsFormatChoices = []string{enumsFormatText, enumsFormatJSON, enumsFormatMarkdown}
+
+// An enumEntry is an enum in the output of the enums subcommand. For the
+// extension members of an enum type declared in a package, the entry is
+// for the enum type, ExtendedBy is the package, and Members are the
+// extension members.
+type enumEntry struct {
+	Package    string        `json:"package"`
+	Name       string        `json:"name"`
+	Position   string        `json:"position"`
+	Doc        string        `json:"doc,omitempty"`
+	Flags      bool          `json:"flags"`
+	Ignored    bool          `json:"ignored"`
+	Vars       bool          `json:"vars"`
+	ExtendedBy string        `json:"extendedBy,omitempty"`
+	Members    []memberEntry `json:"members"`
+}
+
+// notes returns the notes about the enum's status, such as "ignored".
+func (e enumEntry) notes() []string {
+	var notes []string
+	if e.Flags {
+		notes = append(notes, "flags")
+	}
+	if e.Ignored {
+		notes = append(notes, "ignored")
+	}
+	if e.Vars {
+		notes = append(notes, "vars")
+	}
+	if e.ExtendedBy != "" {
+		notes = append(notes, "extension members in "+e.ExtendedBy)
+	}
+	return notes
+}
+
+// A memberEntry is an enum member in the output of the enums subcommand.
+type memberEntry struct {
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	Position   string `json:"position"`
+	Doc        string `json:"doc,omitempty"`
+	Ignored    bool   `json:"ignored"`
+	Sentinel   bool   `json:"sentinel"`
+	Optional   bool   `json:"optional"`
+	Deprecated bool   `json:"deprecated"`
+	Constraint string `json:"constraint,omitempty"`
+}
+
+// notes returns the notes about the member's status, such as "ignored".
+func (m memberEntry) notes() []string {
+	var notes []string
+	if m.Ignored {
+		notes = append(notes, "ignored")
+	}
+	if m.Sentinel {
+		notes = append(notes, "sentinel")
+	}
+	if m.Optional {
+		notes = append(notes, "optional")
+	}
+	if m.Deprecated {
+		notes = append(notes, "deprecated")
+	}
+	if m.Constraint != "" {
+		notes = append(notes, "build: "+m.Constraint)
+	}
+	return notes
+}
+
+// runEnums implements the enums subcommand, which prints the enums
+// declared in the packages named in the arguments. It returns the exit
+// status.
+func runEnums(args []string) int {
+	fs, tests := newFlagSet("exhaustive enums")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: exhaustive enums [flags] [packages]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	format := fs.String("format", enumsFormatText, fmt.Sprintf("output format (%s)", strings.Join(enumsFormatChoices, ", ")))
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	switch *format {
+	case enumsFormatText, enumsFormatJSON, enumsFormatMarkdown:
+	default:
+		fmt.Fprintf(os.Stderr, "exhaustive: invalid -format %q; want one of %s\n", *format, strings.Join(enumsFormatChoices, ", "))
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	_, results, err := analyze(exhaustive.InventoryAnalyzer, fs.Args(), *tests)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "exhaustive: %s\n", err)
+		return 1
+	}
+	wd, _ := os.Getwd()
+	if err := writeEnums(os.Stdout, *format, enumEntries(wd, results)); err != nil {
+		fmt.Fprintf(os.Stderr, "exhaustive: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+func writeEnums(w io.Writer, format string, entries []enumEntry) error {
+	switch format {
+	case enumsFormatJSON:
+		return writeEnumsJSON(w, entries)
+	case enumsFormatMarkdown:
+		return writeEnumsMarkdown(w, entries)
+	default:
+		return writeEnumsText(w, entries)
+	}
+}
+
+// enumEntries returns the enums in the results of the inventory
+// analyzer. An enum found in both a package and its test variant is
+// included once.
+func enumEntries(wd string, results []packageResult) []enumEntry {
+	entries := []enumEntry{}
+	seen := make(map[string]bool) // enum type position, and package of extension members
+	for _, r := range results {
+		p := r.pkg
+		for _, e := range r.result.([]exhaustive.Enum) {
+			posn := p.Fset.Position(e.Type.Pos())
+			var extendedBy string
+			if e.Extension {
+				extendedBy = p.PkgPath
+			}
+			key := posn.String() + " " + extendedBy
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			entry := enumEntry{
+				Package:    e.Type.Pkg().Path(),
+				Name:       e.Type.Name(),
+				Position:   formatPosition(wd, posn),
+				Doc:        e.Doc,
+				Flags:      e.Flags,
+				Ignored:    e.Ignored,
+				Vars:       e.Vars,
+				ExtendedBy: extendedBy,
+				Members:    []memberEntry{},
+			}
+			for _, m := range e.Members {
+				entry.Members = append(entry.Members, memberEntry{
+					Name:       m.Name,
+					Value:      m.Value,
+					Position:   formatPosition(wd, p.Fset.Position(m.Pos)),
+					Doc:        m.Doc,
+					Ignored:    m.Ignored,
+					Sentinel:   m.Sentinel,
+					Optional:   m.Optional,
+					Deprecated: m.Deprecated,
+					Constraint: m.Constraint,
+				})
+			}
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Package < entries[j].Package
+	})
+	return entries
+}
+
+// formatPosition formats the position, with the file name relative to the
+// working directory if possible.
+func formatPosition(wd string, posn token.Position) string {
+	return fmt.Sprintf("%s:%d:%d", relPath(wd, posn.Filename), posn.Line, posn.Column)
+}
+
+func writeEnumsText(w io.Writer, entries []enumEntry) error {
+	var b strings.Builder
+	for _, e := range entries {
+		writeIndented(&b, "// ", e.Doc)
+		fmt.Fprintf(&b, "%s.%s\t%s%s\n", e.Package, e.Name, e.Position, textNotes(e.notes()))
+		for _, m := range e.Members {
+			writeIndented(&b, "\t// ", m.Doc)
+			b.WriteString("\t" + m.Name)
+			if m.Value != "" {
+				b.WriteString(" = " + m.Value)
+			}
+			fmt.Fprintf(&b, "\t%s%s\n", m.Position, textNotes(m.notes()))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func textNotes(notes []string) string {
+	if len(notes) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(notes, ", ") + ")"
+}
+
+// writeIndented writes each line of the text, if any, with the prefix.
+func writeIndented(b *strings.Builder, prefix, text string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(strings.TrimRight(prefix+line, " "))
+		b.WriteString("\n")
+	}
+}
+
+func writeEnumsJSON(w io.Writer, entries []enumEntry) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+func writeEnumsMarkdown(w io.Writer, entries []enumEntry) error {
+	var b strings.Builder
+	var pkg string
+	for i, e := range entries {
+		if i == 0 || e.Package != pkg {
+			pkg = e.Package
+			fmt.Fprintf(&b, "# %s\n\n", pkg)
+		}
+		fmt.Fprintf(&b, "## %s\n\n", e.Name)
+		fmt.Fprintf(&b, "Declared at `%s`.", e.Position)
+		if e.Flags {
+			b.WriteString(" Bit-flag enum.")
+		}
+		if e.Ignored {
+			b.WriteString(" Ignored.")
+		}
+		if e.Vars {
+			b.WriteString(" Members are variables.")
+		}
+		if e.ExtendedBy != "" {
+			fmt.Fprintf(&b, " Extension members in `%s`.", e.ExtendedBy)
+		}
+		b.WriteString("\n\n")
+		if e.Doc != "" {
+			fmt.Fprintf(&b, "%s\n\n", e.Doc)
+		}
+		b.WriteString("| Member | Value | Position | Notes | Doc |\n")
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, m := range e.Members {
+			value := m.Value
+			if value != "" {
+				value = "`" + markdownCell(value) + "`"
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | `%s` | %s | %s |\n",
+				m.Name, value, m.Position, strings.Join(m.notes(), ", "), markdownCell(m.Doc))
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell escapes the text for use in a cell of a Markdown table.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nishanths/exhaustive"
+)
+
+func TestWriteEnums(t *testing.T) {
+	setAnalyzerFlags(t, map[string]string{"ignore-enum-types": `\.Internal$`})
+
+	_, results, err := analyze(exhaustive.InventoryAnalyzer, []string{"./testdata/enums/..."}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := enumEntries(wd, results)
+
+	for _, format := range enumsFormatChoices {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeEnums(&buf, format, entries); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, filepath.Join("testdata", "enums."+format+".golden"), buf.Bytes())
+		})
+	}
+}
@@ -4,11 +4,211 @@
 // # Usage
 //
 //	exhaustive [flags] [packages]
+//	exhaustive enums [flags] [packages]
+//	exhaustive coverage [flags] [packages]
+//	exhaustive impact [flags] <import path>.<type name> [packages]
+//
+// # Output formats
+//
+// By default, the command runs the analyzer the same way as other
+// analysis drivers do, and accepts their flags, such as -fix, -json, and
+// -c. The -format flag selects a machine-readable format for the
+// diagnostics instead:
+//
+//	sarif       SARIF 2.1.0 log, with a rule for each diagnostic category
+//	checkstyle  Checkstyle XML
+//	github      GitHub Actions workflow commands (::warning annotations)
+//	junit       JUnit XML, with a test suite for each package
+//
+// Each diagnostic in a machine-readable format carries its category (for
+// example, "missing-cases" or "invalid-directive") as the rule ID and the
+// source range of the offending node. In SARIF output, diagnostics for
+// missing cases, keys, or elements also list the missing members in the
+// "missing" property.
+//
+// With a machine-readable format, the command accepts the flags of the
+// analyzer and the -test flag (default true), which controls whether
+// test packages are analyzed, writes the output to standard output, and
+// exits with status 3 if there are diagnostics. The flags of other
+// analysis drivers, such as -fix and -json, apply only to the default
+// -format=text.
+//
+// The command can also be run by "go vet -vettool=$(which exhaustive)",
+// in which case go vet selects the packages and prints the diagnostics.
+//
+// # Enums
+//
+// The enums subcommand prints each enum type declared in the packages,
+// with its members, their constant values, positions, and doc comments.
+// It accepts the flags of the analyzer, such as -package-scope-only,
+// -enum-discovery, -ignore-enum-types, and -ignore-enum-members, so that
+// the enums it prints are those that the analyzer enforces; types and
+// members that match the ignore flags are printed and marked as ignored.
+// Types and constants excluded with an "//exhaustive:ignore" comment are
+// not enums and are not printed. The -format flag selects the output
+// format: "text" (the default), "json", or "markdown". For example:
+//
+//	exhaustive enums -format=markdown ./... > ENUMS.md
+//
+// # Coverage
+//
+// The coverage subcommand reports, for each enum type, how many switch
+// statements and map literals in the packages use it, and how many of
+// those are exhaustive, ignored (due to an "//exhaustive:ignore"
+// comment), skipped (due to a missing "//exhaustive:enforce" comment, with
+// -explicit-exhaustive-switch or -explicit-exhaustive-map), made
+// exhaustive by a default case (with -default-signifies-exhaustive), or
+// failing. It accepts the flags of the analyzer; the -check flag defaults
+// to "switch,map". A map literal with a struct key type whose fields are
+// of enum types, which is checked with -check-product-keys, is counted for
+// the enum type of each field. The -format flag selects the output format:
+// "json" (the default) or "html", a summary page that lists the most often
+// suppressed enum types first. For example:
+//
+//	exhaustive coverage -format=html ./... > coverage.html
+//
+// # Impact
+//
+// The impact subcommand lists the switch statements and map literals that
+// switch on or are keyed by an enum type, to show what adding a member to
+// the type would break. Each is classified as one of:
+//
+//	will become non-exhaustive  all members are listed, so a new member would be missing
+//	already non-exhaustive      a diagnostic is already reported
+//	covered by default case     the default case signifies exhaustiveness
+//	ignored                     associated with an "//exhaustive:ignore" comment
+//	not checked                 not associated with an "//exhaustive:enforce" comment, in explicit mode
+//
+// The packages default to "./...", and the -check flag defaults to
+// "switch,map". The output is a list of positions, one per line, or JSON
+// with -format=json. For example:
+//
+//	exhaustive impact example.org/eco.Biome ./...
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/nishanths/exhaustive"
 	"golang.org/x/tools/go/analysis/singlechecker"
 )
 
-func main() { singlechecker.Main(exhaustive.Analyzer) }
+func main() {
+	args := os.Args[1:]
+	if len(args) > 0 {
+		switch args[0] {
+		case "enums":
+			os.Exit(runEnums(args[1:]))
+		case "coverage":
+			os.Exit(runCoverage(args[1:]))
+		case "impact":
+			os.Exit(runImpact(args[1:]))
+		}
+	}
+	if !invokedByGoVet(args) {
+		if format := formatArg(args); format != "" && format != formatText {
+			os.Exit(run(args))
+		}
+		// Accept -format=text alongside the flags of singlechecker.
+		flag.String("format", formatText, formatUsage)
+	}
+	singlechecker.Main(exhaustive.Analyzer)
+}
+
+// invokedByGoVet reports whether the command line arguments are those of
+// "go vet -vettool", which queries the command with the -V and -flags
+// flags and then runs it on a configuration file for each package.
+func invokedByGoVet(args []string) bool {
+	for _, arg := range args {
+		name := strings.TrimLeft(arg, "-")
+		if arg != name && (name == "flags" || name == "V" || strings.HasPrefix(name, "V=")) {
+			return true
+		}
+	}
+	return len(args) != 0 && strings.HasSuffix(args[len(args)-1], ".cfg")
+}
+
+// formatArg returns the value of the last -format flag in the command
+// line arguments, or "" if there is none.
+func formatArg(args []string) string {
+	var format string
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if name == arg {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(name, "format="):
+			format = strings.TrimPrefix(name, "format=")
+		case name == "format" && i+1 < len(args):
+			format = args[i+1]
+		}
+	}
+	return format
+}
+
+var formatUsage = fmt.Sprintf("output `format` (%s)", strings.Join(formatChoices, ", "))
+
+// run analyzes the packages named in the arguments and writes the
+// diagnostics to standard output in the machine-readable format selected
+// by the -format flag. It returns the exit status.
+func run(args []string) int {
+	fs, tests := newFlagSet("exhaustive")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: exhaustive -format=<format> [flags] [packages]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	format := fs.String("format", formatText, formatUsage)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if !validFormat(*format) || *format == formatText {
+		fmt.Fprintf(os.Stderr, "exhaustive: invalid -format %q; want one of %s\n", *format, strings.Join(formatChoices, ", "))
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	findings, _, err := analyze(exhaustive.Analyzer, fs.Args(), *tests)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "exhaustive: %s\n", err)
+		return 1
+	}
+	wd, _ := os.Getwd()
+	if err := writeFindings(os.Stdout, *format, wd, findings); err != nil {
+		fmt.Fprintf(os.Stderr, "exhaustive: %s\n", err)
+		return 1
+	}
+	if len(findings) > 0 {
+		return 3
+	}
+	return 0
+}
+
+// newFlagSet returns a flag set with the flags of the analyzer and the
+// -test flag.
+func newFlagSet(name string) (fs *flag.FlagSet, tests *bool) {
+	fs = flag.NewFlagSet(name, flag.ContinueOnError)
+	exhaustive.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	tests = fs.Bool("test", true, "indicates whether test files should be analyzed, too")
+	return fs, tests
+}
+
+func validFormat(format string) bool {
+	for _, f := range formatChoices {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
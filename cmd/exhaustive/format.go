@@ -0,0 +1,324 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/nishanths/exhaustive"
+)
+
+// Values for the -format flag. The text format is handled by
+// singlechecker; see main.
+const (
+	formatText       = "text"
+	formatSARIF      = "sarif"
+	formatCheckstyle = "checkstyle"
+	formatGitHub     = "github"
+	formatJUnit      = "junit"
+)
+
+var formatChoices = []string{formatText, formatSARIF, formatCheckstyle, formatGitHub, formatJUnit}
+
+// A rule describes the diagnostics of a category.
+type rule struct {
+	id          string // the diagnostic category
+	description string
+}
+
+var rules = []rule{
+	{exhaustive.CategoryMissingCases, "Switch statement, type switch statement, or if-else chain is missing cases for enum members"},
+	{exhaustive.CategoryMissingKeys, "Map is missing keys for enum members"},
+	{exhaustive.CategoryMissingElements, "Array or slice literal is missing elements for enum members"},
+	{exhaustive.CategoryMissingDefault, "Switch statement is missing a required default case"},
+	{exhaustive.CategoryInvalidDirective, "Comment directive is invalid"},
+	{exhaustive.CategoryUnnecessaryIgnore, "Ignore directive is unnecessary"},
+	{exhaustive.CategoryMemberValue, "Expression has the value of an enum member"},
+	{exhaustive.CategoryStrayMember, "Constant of an enum type is not an enum member"},
+	{exhaustive.CategoryOutsideGroup, "Case expression is outside the enforced group"},
+	{exhaustive.CategoryDeprecatedMember, "Reference to a deprecated enum member"},
+	{exhaustive.CategoryFixUnavailable, "Suggested fix refers to a package that cannot be resolved"},
+}
+
+// missingMembers returns the missing members of a finding, if its
+// category is one of the missing-* categories. The analyzer lists them as
+// the related information of the diagnostic. Each element is a member
+// name, such as "pkg.A", names of same-valued members joined by "|", or a
+// parenthesized combination of members.
+func missingMembers(f finding) []string {
+	switch f.diag.Category {
+	case exhaustive.CategoryMissingCases, exhaustive.CategoryMissingKeys, exhaustive.CategoryMissingElements:
+	default:
+		return nil
+	}
+	var out []string
+	for _, r := range f.diag.Related {
+		out = append(out, r.Message)
+	}
+	return out
+}
+
+// relPath returns the file name relative to the working directory, with
+// forward slashes, if possible.
+func relPath(wd, filename string) string {
+	if rel, err := filepath.Rel(wd, filename); err == nil && !strings.HasPrefix(rel, "..") {
+		filename = rel
+	}
+	return filepath.ToSlash(filename)
+}
+
+func writeFindings(w io.Writer, format, wd string, findings []finding) error {
+	switch format {
+	case formatSARIF:
+		return writeSARIF(w, wd, findings)
+	case formatCheckstyle:
+		return writeCheckstyle(w, wd, findings)
+	case formatGitHub:
+		return writeGitHub(w, wd, findings)
+	case formatJUnit:
+		return writeJUnit(w, wd, findings)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// SARIF 2.1.0; see https://docs.oasis-open.org/sarif/sarif/v2.1.0/.
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations"`
+	Properties *sarifPropertyBag `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifPropertyBag struct {
+	Missing []string `json:"missing"`
+}
+
+func writeSARIF(w io.Writer, wd string, findings []finding) error {
+	driver := sarifDriver{
+		Name:           "exhaustive",
+		InformationURI: "https://github.com/nishanths/exhaustive",
+	}
+	for _, r := range rules {
+		driver.Rules = append(driver.Rules, sarifRule{r.id, sarifMessage{r.description}})
+	}
+	results := []sarifResult{}
+	for _, f := range findings {
+		res := sarifResult{
+			RuleID:  f.diag.Category,
+			Level:   "warning",
+			Message: sarifMessage{f.diag.Message},
+			Locations: []sarifLocation{{sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{relPath(wd, f.start.Filename)},
+				Region: sarifRegion{
+					StartLine:   f.start.Line,
+					StartColumn: f.start.Column,
+					EndLine:     f.end.Line,
+					EndColumn:   f.end.Column,
+				},
+			}}},
+		}
+		if missing := missingMembers(f); missing != nil {
+			res.Properties = &sarifPropertyBag{missing}
+		}
+		results = append(results, res)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{{sarifTool{driver}, results}},
+	})
+}
+
+// Checkstyle XML, as produced by checkstyle and consumed by many CI
+// systems.
+
+type checkstyleOutput struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func writeCheckstyle(w io.Writer, wd string, findings []finding) error {
+	out := checkstyleOutput{Version: "5.0"}
+	for _, f := range findings {
+		name := relPath(wd, f.start.Filename)
+		if len(out.Files) == 0 || out.Files[len(out.Files)-1].Name != name {
+			out.Files = append(out.Files, checkstyleFile{Name: name})
+		}
+		file := &out.Files[len(out.Files)-1]
+		file.Errors = append(file.Errors, checkstyleError{
+			Line:     f.start.Line,
+			Column:   f.start.Column,
+			Severity: "warning",
+			Message:  f.diag.Message,
+			Source:   "exhaustive." + f.diag.Category,
+		})
+	}
+	return writeXML(w, out)
+}
+
+// GitHub Actions workflow commands; see
+// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions.
+
+func writeGitHub(w io.Writer, wd string, findings []finding) error {
+	for _, f := range findings {
+		_, err := fmt.Fprintf(w, "::warning file=%s,line=%d,col=%d,endLine=%d,endColumn=%d,title=%s::%s\n",
+			githubEscapeProperty(relPath(wd, f.start.Filename)),
+			f.start.Line,
+			f.start.Column,
+			f.end.Line,
+			f.end.Column,
+			githubEscapeProperty("exhaustive ("+f.diag.Category+")"),
+			githubEscapeData(f.diag.Message),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func githubEscapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func githubEscapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// JUnit XML, with a test suite for each package that has findings and a
+// failed test case for each finding.
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Failure   junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func writeJUnit(w io.Writer, wd string, findings []finding) error {
+	var out junitTestSuites
+	index := make(map[string]int) // package path -> index in out.Suites
+	for _, f := range findings {
+		i, ok := index[f.pkg]
+		if !ok {
+			i = len(out.Suites)
+			index[f.pkg] = i
+			out.Suites = append(out.Suites, junitTestSuite{Name: f.pkg})
+		}
+		s := &out.Suites[i]
+		posn := fmt.Sprintf("%s:%d:%d", relPath(wd, f.start.Filename), f.start.Line, f.start.Column)
+		s.Tests++
+		s.Failures++
+		s.Cases = append(s.Cases, junitTestCase{
+			Name:      posn,
+			ClassName: f.pkg,
+			Failure: junitFailure{
+				Message: f.diag.Message,
+				Type:    f.diag.Category,
+				Text:    posn + ": " + f.diag.Message,
+			},
+		})
+	}
+	return writeXML(w, out)
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
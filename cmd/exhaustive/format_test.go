@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nishanths/exhaustive"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// setAnalyzerFlags sets the flags of the analyzer for the duration of the
+// test.
+func setAnalyzerFlags(t *testing.T, values map[string]string) {
+	t.Helper()
+	for name, value := range values {
+		f := exhaustive.Analyzer.Flags.Lookup(name)
+		if f == nil {
+			t.Fatalf("no flag %q", name)
+		}
+		old := f.Value.String()
+		if err := f.Value.Set(value); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Value.Set(old) })
+	}
+}
+
+// checkGolden compares got with the contents of the golden file, or
+// updates the file with the -update flag.
+func checkGolden(t *testing.T, golden string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s:\ngot:\n%s\nwant:\n%s", golden, got, want)
+	}
+}
+
+func TestWriteFindings(t *testing.T) {
+	setAnalyzerFlags(t, map[string]string{"check": "switch,map"})
+
+	findings, _, err := analyze(exhaustive.Analyzer, []string{"./testdata/format/..."}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range formatChoices {
+		if format == formatText {
+			continue // handled by singlechecker
+		}
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeFindings(&buf, format, wd, findings); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, filepath.Join("testdata", "format."+format+".golden"), buf.Bytes())
+		})
+	}
+}
+
+func TestFormatArg(t *testing.T) {
+	testCases := []struct {
+		args []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"./..."}, ""},
+		{[]string{"-format=sarif", "./..."}, "sarif"},
+		{[]string{"--format", "junit", "./..."}, "junit"},
+		{[]string{"-c=1", "-format", "github", "-format=text", "./..."}, "text"},
+		{[]string{"--", "-format=sarif"}, ""},
+		{[]string{"-format"}, ""},
+	}
+	for _, tc := range testCases {
+		if got := formatArg(tc.args); got != tc.want {
+			t.Errorf("formatArg(%q) = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nishanths/exhaustive"
+)
+
+// Values for the -format flag of the impact subcommand.
+const (
+	impactFormatText = "text"
+	impactFormatJSON = "json"
+)
+
+var impactFormatChoices = []string{impactFormatText, impactFormatJSON}
+
+// impact is the effect, on a switch statement or a map literal, of adding
+// a member to an enum type.
+type impact string
+
+const (
+	impactBreaks         impact = "will become non-exhaustive"
+	impactAlreadyFailing impact = "already non-exhaustive"
+	impactDefault        impact = "covered by default case"
+	impactIgnored        impact = "ignored"
+	impactNotChecked     impact = "not checked"
+)
+
+// impactOf returns the impact of adding a member to an enum type on the
+// switch statement or map literal of the check.
+func impactOf(c exhaustive.Check, defaultSignifiesExhaustive bool) impact {
+	switch c.Outcome {
+	case exhaustive.OutcomeExhaustive:
+		if c.Default && defaultSignifiesExhaustive {
+			return impactDefault
+		}
+		return impactBreaks
+	case exhaustive.OutcomeDefault:
+		return impactDefault
+	case exhaustive.OutcomeFailing:
+		return impactAlreadyFailing
+	case exhaustive.OutcomeIgnored:
+		return impactIgnored
+	}
+	return impactNotChecked
+}
+
+// An impactEntry is a switch statement or map literal in the output of the
+// impact subcommand.
+type impactEntry struct {
+	Position string `json:"position"`
+	Node     string `json:"node"`
+	Impact   impact `json:"impact"`
+}
+
+// runImpact implements the impact subcommand, which lists the switch
+// statements and map literals, in the packages named in the arguments,
+// whose switch tag or key type is composed of the enum type named by the
+// first argument, along with the effect on each of adding a member to the
+// enum type. It returns the exit status.
+func runImpact(args []string) int {
+	fs, tests := newFlagSet("exhaustive impact")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: exhaustive impact [flags] <import path>.<type name> [packages]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	// Switch statements and map literals are the subject of the impact
+	// analysis, so check both unless the -check flag says otherwise.
+	if err := fs.Set(exhaustive.CheckFlag, "switch,map"); err != nil {
+		panic(err)
+	}
+	format := fs.String("format", impactFormatText, fmt.Sprintf("output format (%s)", strings.Join(impactFormatChoices, ", ")))
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	switch *format {
+	case impactFormatText, impactFormatJSON:
+	default:
+		fmt.Fprintf(os.Stderr, "exhaustive: invalid -format %q; want one of %s\n", *format, strings.Join(impactFormatChoices, ", "))
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	typ := fs.Arg(0)
+	if i := strings.LastIndex(typ, "."); i <= 0 || i == len(typ)-1 || strings.Contains(typ[i:], "/") {
+		fmt.Fprintf(os.Stderr, "exhaustive: %q is not a package-qualified type name, such as example.org/eco.Biome\n", typ)
+		return 2
+	}
+	patterns := fs.Args()[1:]
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	defaultSignifiesExhaustive := fs.Lookup(exhaustive.DefaultSignifiesExhaustiveFlag).Value.String() == "true"
+
+	_, results, err := analyze(exhaustive.CheckAnalyzer, patterns, *tests)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "exhaustive: %s\n", err)
+		return 1
+	}
+	wd, _ := os.Getwd()
+	entries := impactEntries(wd, results, typ, defaultSignifiesExhaustive)
+	if len(entries) == 0 {
+		fmt.Fprintf(os.Stderr, "exhaustive: no switch statements or map literals of enum type %s\n", typ)
+	}
+
+	if err := writeImpact(os.Stdout, *format, entries); err != nil {
+		fmt.Fprintf(os.Stderr, "exhaustive: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+func writeImpact(w io.Writer, format string, entries []impactEntry) error {
+	if format == impactFormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+	return writeImpactText(w, entries)
+}
+
+// impactEntries returns the switch statements and map literals, in the
+// results of the analyzer, of the enum type typ, sorted by position. A
+// check in both a package and its test variant is included once.
+func impactEntries(wd string, results []packageResult, typ string, defaultSignifiesExhaustive bool) []impactEntry {
+	type entry struct {
+		file  string
+		line  int
+		col   int
+		value impactEntry
+	}
+	var entries []entry
+	seen := make(map[string]bool) // check position
+	for _, r := range results {
+		p := r.pkg
+		for _, c := range r.result.([]exhaustive.Check) {
+			if !hasType(c, typ) {
+				continue
+			}
+			posn := p.Fset.Position(c.Pos)
+			if seen[posn.String()] {
+				continue
+			}
+			seen[posn.String()] = true
+			entries = append(entries, entry{
+				file: posn.Filename,
+				line: posn.Line,
+				col:  posn.Column,
+				value: impactEntry{
+					Position: formatPosition(wd, posn),
+					Node:     string(c.Node),
+					Impact:   impactOf(c, defaultSignifiesExhaustive),
+				},
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		x, y := entries[i], entries[j]
+		if x.file != y.file {
+			return x.file < y.file
+		}
+		if x.line != y.line {
+			return x.line < y.line
+		}
+		return x.col < y.col
+	})
+
+	out := []impactEntry{}
+	for _, e := range entries {
+		out = append(out, e.value)
+	}
+	return out
+}
+
+// hasType reports whether typ, a package-qualified type name, is one of
+// the enum types of the check.
+func hasType(c exhaustive.Check, typ string) bool {
+	for _, tn := range c.Types {
+		if enumTypeName(tn) == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func writeImpactText(w io.Writer, entries []impactEntry) error {
+	var b strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&b, "%s: %s (%s)\n", e.Position, e.Impact, e.Node)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/nishanths/exhaustive"
+)
+
+func TestWriteImpact(t *testing.T) {
+	const typ = "github.com/nishanths/exhaustive/cmd/exhaustive/testdata/impact.Status"
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		defaultSignifiesExhaustive bool
+		golden                     string
+	}{
+		{false, "impact"},
+		{true, "impact-default"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.golden, func(t *testing.T) {
+			setAnalyzerFlags(t, map[string]string{
+				"check":                        "switch,map",
+				"default-signifies-exhaustive": strconv.FormatBool(tc.defaultSignifiesExhaustive),
+			})
+
+			_, results, err := analyze(exhaustive.CheckAnalyzer, []string{"./testdata/impact/..."}, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			entries := impactEntries(wd, results, typ, tc.defaultSignifiesExhaustive)
+
+			for _, format := range impactFormatChoices {
+				t.Run(format, func(t *testing.T) {
+					var buf bytes.Buffer
+					if err := writeImpact(&buf, format, entries); err != nil {
+						t.Fatal(err)
+					}
+					checkGolden(t, filepath.Join("testdata", tc.golden+"."+format+".golden"), buf.Bytes())
+				})
+			}
+		})
+	}
+}
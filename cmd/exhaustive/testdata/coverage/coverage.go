@@ -0,0 +1,43 @@
+package coverage
+
+type Direction int
+
+const (
+	N Direction = iota
+	E
+	S
+	W
+)
+
+type Suit string
+
+const (
+	Hearts Suit = "hearts"
+	Spades Suit = "spades"
+)
+
+func _(d Direction, s Suit) {
+	switch d {
+	case N, E, S, W:
+	}
+
+	//exhaustive:ignore
+	switch d {
+	case N:
+	}
+
+	switch d {
+	case N:
+	}
+
+	switch s {
+	case Hearts:
+	default:
+	}
+}
+
+var _ = map[Suit]int{
+	Hearts: 1,
+}
+
+var _ = map[Direction]int{N: 1, E: 2, S: 3, W: 4}
@@ -0,0 +1,13 @@
+package use
+
+import "github.com/nishanths/exhaustive/cmd/exhaustive/testdata/coverage"
+
+func _(d coverage.Direction) {
+	//exhaustive:ignore
+	switch d {
+	case coverage.N:
+	}
+
+	//exhaustive:ignore
+	_ = map[coverage.Direction]int{coverage.N: 1}
+}
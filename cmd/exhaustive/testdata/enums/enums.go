@@ -0,0 +1,47 @@
+package enums
+
+import "errors"
+
+// Direction is a direction.
+type Direction int
+
+const (
+	// N is north.
+	N Direction = iota
+	E
+	S
+	W
+	// Deprecated: Use N instead.
+	Up = N
+)
+
+//exhaustive:flags
+type Perm uint8
+
+const (
+	Read Perm = 1 << iota
+	Write
+)
+
+type Internal int
+
+const (
+	InternalA Internal = iota
+	InternalB
+)
+
+//exhaustive:enum
+type Shape struct{ sides int }
+
+var (
+	Triangle = &Shape{3}
+	Square   = &Shape{4}
+)
+
+// Errors returned by the package.
+//
+//exhaustive:enum
+var (
+	ErrNotFound = errors.New("not found")
+	ErrTimeout  = errors.New("timeout")
+)
@@ -0,0 +1,8 @@
+package plugin
+
+import "github.com/nishanths/exhaustive/cmd/exhaustive/testdata/enums"
+
+// NE is north-east.
+//
+//exhaustive:member-of enums.Direction
+const NE enums.Direction = 100
@@ -0,0 +1,22 @@
+package format
+
+type Direction int
+
+const (
+	N Direction = iota
+	E
+	S
+	W
+	North = N
+)
+
+func _(d Direction) {
+	switch d {
+	case E:
+	}
+
+	//exhaustive:ignor
+	switch d {
+	case N, E, S, W:
+	}
+}
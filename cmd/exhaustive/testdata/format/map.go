@@ -0,0 +1,6 @@
+package format
+
+var _ = map[Direction]string{
+	N: "north",
+	S: "south",
+}
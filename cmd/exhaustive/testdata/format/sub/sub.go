@@ -0,0 +1,9 @@
+package sub
+
+import "github.com/nishanths/exhaustive/cmd/exhaustive/testdata/format"
+
+func _(d format.Direction) {
+	switch d {
+	case format.N, format.E, format.S:
+	}
+}
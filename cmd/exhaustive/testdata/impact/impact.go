@@ -0,0 +1,23 @@
+package impact
+
+type Status int
+
+const (
+	Active Status = iota
+	Inactive
+)
+
+func _(s Status) {
+	switch s {
+	case Active, Inactive:
+	}
+
+	switch s {
+	case Active:
+	}
+
+	switch s {
+	case Active:
+	default:
+	}
+}
@@ -0,0 +1,23 @@
+package other
+
+import "github.com/nishanths/exhaustive/cmd/exhaustive/testdata/impact"
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+)
+
+// Switches and maps of other enum types aren't listed.
+func _(c Color, s impact.Status) {
+	switch c {
+	case Red, Green:
+	}
+
+	switch int(s) {
+	case 0:
+	}
+}
+
+var _ = map[Color]impact.Status{Red: impact.Active}
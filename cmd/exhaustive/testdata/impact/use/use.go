@@ -0,0 +1,22 @@
+package use
+
+import "github.com/nishanths/exhaustive/cmd/exhaustive/testdata/impact"
+
+var _ = map[impact.Status]string{
+	impact.Active:   "active",
+	impact.Inactive: "inactive",
+}
+
+func _(s impact.Status) {
+	//exhaustive:ignore
+	switch s {
+	case impact.Active:
+	}
+
+	// A switch listing each member and a default case breaks unless
+	// -default-signifies-exhaustive is set.
+	switch s {
+	case impact.Active, impact.Inactive:
+	default:
+	}
+}
@@ -13,6 +13,12 @@ const (
 	enforceComment                    = "enforce"
 	ignoreDefaultCaseRequiredComment  = "ignore-default-case-required"
 	enforceDefaultCaseRequiredComment = "enforce-default-case-required"
+	sealedComment                     = "sealed"
+	enumComment                       = "enum"
+	excludeComment                    = "exclude"
+	flagsComment                      = "flags"
+	memberOfComment                   = "member-of"
+	groupComment                      = "group"
 )
 
 type directive int64
@@ -22,10 +28,42 @@ const (
 	enforceDirective
 	ignoreDefaultCaseRequiredDirective
 	enforceDefaultCaseRequiredDirective
+	sealedDirective
+	enumDirective
+	excludeDirective
+	flagsDirective
+	memberOfDirective
+	groupDirective
 )
 
 type directiveSet int64
 
+// directiveNames lists the valid directive names, which are used for
+// suggesting corrections to invalid directives.
+var directiveNames = []string{
+	ignoreComment,
+	enforceComment,
+	ignoreDefaultCaseRequiredComment,
+	enforceDefaultCaseRequiredComment,
+	sealedComment,
+	enumComment,
+	excludeComment,
+	flagsComment,
+	memberOfComment,
+	groupComment,
+}
+
+// directiveError is the error for a comment that is an invalid
+// directive, or that is likely intended to be a directive but is not
+// correctly written as one.
+type directiveError struct {
+	comment    *ast.Comment
+	msg        string
+	suggestion string // corrected comment text; empty if no suggestion
+}
+
+func (e *directiveError) Error() string { return e.msg }
+
 func parseDirectives(commentGroups []*ast.CommentGroup) (directiveSet, error) {
 	var out directiveSet
 	for _, commentGroup := range commentGroups {
@@ -35,6 +73,13 @@ func parseDirectives(commentGroups []*ast.CommentGroup) (directiveSet, error) {
 		for _, comment := range commentGroup.List {
 			commentLine := comment.Text
 			if !strings.HasPrefix(commentLine, exhaustiveComment) {
+				if suggestion, ok := correctDirective(commentLine); ok {
+					return out, &directiveError{
+						comment:    comment,
+						msg:        fmt.Sprintf("malformed directive %q", commentLine),
+						suggestion: suggestion,
+					}
+				}
 				continue
 			}
 			directive := commentLine[len(exhaustiveComment):]
@@ -50,14 +95,162 @@ func parseDirectives(commentGroups []*ast.CommentGroup) (directiveSet, error) {
 				out |= ignoreDefaultCaseRequiredDirective
 			case enforceDefaultCaseRequiredComment:
 				out |= enforceDefaultCaseRequiredDirective
+			case sealedComment:
+				out |= sealedDirective
+			case enumComment:
+				out |= enumDirective
+			case excludeComment:
+				out |= excludeDirective
+			case flagsComment:
+				out |= flagsDirective
+			case memberOfComment:
+				out |= memberOfDirective
+			case groupComment:
+				out |= groupDirective
 			default:
-				return out, fmt.Errorf("invalid directive %q", directive)
+				suggestion, _ := correctDirective(commentLine)
+				return out, &directiveError{
+					comment:    comment,
+					msg:        fmt.Sprintf("invalid directive %q", directive),
+					suggestion: suggestion,
+				}
 			}
 		}
 	}
 	return out, out.validate()
 }
 
+// findDirectiveComment returns the first comment in the comment groups
+// that is the named directive. It returns nil if there is no such
+// comment.
+func findDirectiveComment(commentGroups []*ast.CommentGroup, name string) *ast.Comment {
+	for _, commentGroup := range commentGroups {
+		if commentGroup == nil {
+			continue
+		}
+		for _, comment := range commentGroup.List {
+			if directive, _, ok := splitDirective(comment.Text); ok && directive == name {
+				return comment
+			}
+		}
+	}
+	return nil
+}
+
+// directiveArgs returns the text following the directive name, with
+// surrounding white space removed, of each comment in the comment groups
+// that is the named directive.
+func directiveArgs(commentGroups []*ast.CommentGroup, name string) []string {
+	var out []string
+	for _, commentGroup := range commentGroups {
+		if commentGroup == nil {
+			continue
+		}
+		for _, comment := range commentGroup.List {
+			if directive, args, ok := splitDirective(comment.Text); ok && directive == name {
+				out = append(out, args)
+			}
+		}
+	}
+	return out
+}
+
+// splitDirective splits the comment text, if it is a directive, into the
+// directive name and the text following it, with surrounding white space
+// removed.
+func splitDirective(text string) (directive, args string, ok bool) {
+	if !strings.HasPrefix(text, exhaustiveComment) {
+		return "", "", false
+	}
+	directive = text[len(exhaustiveComment):]
+	if whiteSpaceIndex := strings.IndexAny(directive, " \t"); whiteSpaceIndex != -1 {
+		directive, args = directive[:whiteSpaceIndex], directive[whiteSpaceIndex:]
+	}
+	return directive, strings.TrimSpace(args), true
+}
+
+// correctDirective reports whether the comment text is a near miss for a
+// directive and, if so, returns the corrected comment text. Near misses
+// include spacing and capitalization variants, such as
+// "// exhaustive:ignore" and "//exhaustive: ignore", and misspelled
+// directive names, such as "//exhaustive:ignroe". Any text following the
+// directive name is preserved.
+func correctDirective(text string) (string, bool) {
+	const prefix = "exhaustive"
+
+	if !strings.HasPrefix(text, "//") {
+		return "", false
+	}
+	s := strings.TrimLeft(text[len("//"):], " \t")
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return "", false
+	}
+	s = strings.TrimLeft(s[len(prefix):], " \t")
+	if !strings.HasPrefix(s, ":") {
+		return "", false
+	}
+	s = strings.TrimLeft(s[len(":"):], " \t")
+
+	word, rest := s, ""
+	if i := strings.IndexAny(s, " \t"); i != -1 {
+		word, rest = s[:i], s[i:]
+	}
+	name, ok := closestDirectiveName(word)
+	if !ok {
+		return "", false
+	}
+	corrected := exhaustiveComment + name + rest
+	if corrected == text {
+		return "", false
+	}
+	return corrected, true
+}
+
+// closestDirectiveName returns the directive name closest to the word, if
+// the word is a likely misspelling of it.
+func closestDirectiveName(word string) (string, bool) {
+	const maxDistance = 2
+
+	word = strings.ToLower(word)
+	best, bestDistance := "", maxDistance+1
+	for _, name := range directiveNames {
+		if d := editDistance(word, name); d < bestDistance && d < len(name)/2 {
+			best, bestDistance = name, d
+		}
+	}
+	return best, best != ""
+}
+
+// editDistance returns the Levenshtein distance between the strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(x int, ys ...int) int {
+	for _, y := range ys {
+		if y < x {
+			x = y
+		}
+	}
+	return x
+}
+
 func (d directiveSet) has(directive directive) bool {
 	return int64(d)&int64(directive) != 0
 }
@@ -74,6 +267,34 @@ func (d directiveSet) validate() error {
 	return nil
 }
 
+// declarationDirectiveNames maps the directives that apply to
+// declarations, such as those of enum types, rather than to the program
+// elements that are checked for exhaustiveness, to their names.
+var declarationDirectiveNames = []struct {
+	directive directive
+	name      string
+}{
+	{sealedDirective, sealedComment},
+	{enumDirective, enumComment},
+	{excludeDirective, excludeComment},
+	{flagsDirective, flagsComment},
+	{memberOfDirective, memberOfComment},
+	{groupDirective, groupComment},
+}
+
+// validateUse returns an error if the directives, which are associated
+// with a program element, described by element, that is checked
+// for exhaustiveness, include a directive that applies only to
+// declarations, other than the allowed directives.
+func (d directiveSet) validateUse(element string, allowed directive) error {
+	for _, x := range declarationDirectiveNames {
+		if d.has(x.directive) && x.directive&allowed == 0 {
+			return fmt.Errorf("%q directive does not apply to %s", x.name, element)
+		}
+	}
+	return nil
+}
+
 func fileCommentMap(fset *token.FileSet, file *ast.File) ast.CommentMap {
 	return ast.NewCommentMap(fset, file, file.Comments)
 }
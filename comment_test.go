@@ -181,3 +181,36 @@ func TestDirectives(t *testing.T) {
 		}
 	})
 }
+
+func TestCorrectDirective(t *testing.T) {
+	for _, tt := range []struct {
+		text string
+		want string // empty if no correction
+	}{
+		{"//exhaustive:ignore", ""},
+		{"//exhaustive:enforce some explanation", ""},
+		{"// exhaustive:ignore", "//exhaustive:ignore"},
+		{"//\texhaustive:ignore", "//exhaustive:ignore"},
+		{"//exhaustive: ignore", "//exhaustive:ignore"},
+		{"//exhaustive : enforce", "//exhaustive:enforce"},
+		{"//EXHAUSTIVE:IGNORE", "//exhaustive:ignore"},
+		{"//exhaustive:ignroe", "//exhaustive:ignore"},
+		{"//exhaustive:enfocre foo bar", "//exhaustive:enforce foo bar"},
+		{"//exhaustive:enforce-default-case-require", "//exhaustive:enforce-default-case-required"},
+		{"//exhaustive:foo", ""},
+		{"//exhaustive:", ""},
+		{"//exhauster:ignore", ""},
+		{"// exhaustive checks", ""},
+		{"// exhaustive: see the docs", ""},
+		{"/* exhaustive:ignore */", ""},
+	} {
+		got, ok := correctDirective(tt.text)
+		if ok != (tt.want != "") {
+			t.Errorf("%q: got ok %v, want %v", tt.text, ok, tt.want != "")
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
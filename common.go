@@ -29,6 +29,9 @@ func fromNamed(pass *analysis.Pass, t *types.Named, typeparam bool) (result []en
 
 	et := enumType{t.Obj()}
 	if em, ok := importFact(pass, et); ok {
+		return append([]enumTypeAndMembers{{et, em}}, extensionsOf(pass, et, em)...), true
+	}
+	if em, ok := importVarFact(pass, et.factObject()); ok {
 		return []enumTypeAndMembers{{et, em}}, true
 	}
 
@@ -82,6 +85,18 @@ func fromType(pass *analysis.Pass, t types.Type, typeparam bool) (result []enumT
 	case *types.TypeParam:
 		return fromTypeParam(pass, t, typeparam)
 
+	case *types.Pointer:
+		// The members of a variable enum type T may be of type *T.
+		named, ok := t.Elem().(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			return nil, true
+		}
+		et := enumType{named.Obj()}
+		if em, ok := importVarFact(pass, et.factObject()); ok {
+			return []enumTypeAndMembers{{et, em}}, true
+		}
+		return nil, true
+
 	case *types.Interface:
 		if !typeparam {
 			return nil, true
@@ -142,7 +157,9 @@ func denotesPackage(ident *ast.Ident, info *types.Info) bool {
 // exprConstVal returns the constantValue for an expression if the
 // expression is a constant value and if the expression is considered
 // valid to satisfy exhaustiveness as defined by this program.
-// Otherwise it returns (_, false).
+// Otherwise it returns (_, false). A bitwise OR of such expressions is
+// considered to have a flagsValue, so that it can satisfy exhaustiveness
+// for bit-flag enums. See exprMemberVal for variable enums.
 func exprConstVal(e ast.Expr, info *types.Info) (constantValue, bool) {
 	handleIdent := func(ident *ast.Ident) (constantValue, bool) {
 		obj := info.Uses[ident]
@@ -197,6 +214,23 @@ func exprConstVal(e ast.Expr, info *types.Info) (constantValue, bool) {
 	case *ast.Ident:
 		return handleIdent(e)
 
+	case *ast.BinaryExpr:
+		// A combination of bit flags, such as A|B.
+		if e.Op != token.OR {
+			return "", false
+		}
+		if _, ok := exprConstVal(e.X, info); !ok {
+			return "", false
+		}
+		if _, ok := exprConstVal(e.Y, info); !ok {
+			return "", false
+		}
+		tv, ok := info.Types[e]
+		if !ok || tv.Value == nil {
+			return "", false
+		}
+		return flagsValue(tv.Value), true
+
 	case *ast.SelectorExpr:
 		x := astutil.Unparen(e.X)
 		// Ensure we only see the form pkg.Const, and not e.g.
@@ -258,6 +292,7 @@ type checklist struct {
 	checkl           map[member]struct{}
 	ignoreConstantRe *regexp.Regexp
 	ignoreTypeRe     *regexp.Regexp
+	deprecated       bool // whether to require deprecated members of enums in other packages
 }
 
 func (c *checklist) ignoreConstant(pattern *regexp.Regexp) {
@@ -268,14 +303,24 @@ func (c *checklist) ignoreType(pattern *regexp.Regexp) {
 	c.ignoreTypeRe = pattern
 }
 
-func (*checklist) reMatch(re *regexp.Regexp, s string) bool {
+func (c *checklist) requireDeprecated(require bool) {
+	c.deprecated = require
+}
+
+// reMatch reports whether the regular expression, which can be nil,
+// matches s.
+func reMatch(re *regexp.Regexp, s string) bool {
 	if re == nil {
 		return false
 	}
 	return re.MatchString(s)
 }
 
-func (c *checklist) add(et enumType, em enumMembers, includeUnexported bool) {
+// add adds the members of the enum type that are required to satisfy
+// exhaustiveness. samePackage reports whether the enum type is declared in
+// the package being checked; unexported members, and, unless required,
+// deprecated members, are required only if it is true.
+func (c *checklist) add(et enumType, em enumMembers, samePackage bool) {
 	addOne := func(name string) {
 		if isBlankIdentifier(name) {
 			// Blank identifier is often used to skip entries in iota
@@ -285,13 +330,28 @@ func (c *checklist) add(et enumType, em enumMembers, includeUnexported bool) {
 			// exhaustiveness.
 			return
 		}
-		if !ast.IsExported(name) && !includeUnexported {
+		if !ast.IsExported(name) && !samePackage {
+			return
+		}
+		if em.Sentinels[name] {
+			// Sentinels, such as numKinds, aren't real members.
+			return
+		}
+		if em.Optional[name] {
 			return
 		}
-		if c.reMatch(c.ignoreConstantRe, fmt.Sprintf("%s.%s", et.Pkg().Path(), name)) {
+		if _, ok := em.Deprecated[name]; ok && !samePackage && !c.deprecated {
 			return
 		}
-		if c.reMatch(c.ignoreTypeRe, fmt.Sprintf("%s.%s", et.Pkg().Path(), et.TypeName.Name())) {
+		if _, ok := isFlagMember(em, name); em.Flags && !ok {
+			// Only the single-bit members of a bit-flag enum have to
+			// be accounted for.
+			return
+		}
+		if reMatch(c.ignoreConstantRe, fmt.Sprintf("%s.%s", et.Pkg().Path(), name)) {
+			return
+		}
+		if reMatch(c.ignoreTypeRe, typeKey(et.declared())) {
 			return
 		}
 		mem := member{
@@ -317,8 +377,26 @@ func (c *checklist) add(et enumType, em enumMembers, includeUnexported bool) {
 }
 
 func (c *checklist) found(val constantValue) {
-	// delete all same-valued items.
 	for et, em := range c.info {
+		if em.Flags {
+			// delete all single-bit items whose bit is in the value.
+			bits, ok := flagBits(val)
+			if !ok {
+				continue
+			}
+			for _, name := range em.Names {
+				if bit, ok := isFlagMember(em, name); ok && bits&bit != 0 {
+					delete(c.checkl, member{
+						em.NameToPos[name],
+						et,
+						name,
+						em.NameToValue[name],
+					})
+				}
+			}
+			continue
+		}
+		// delete all same-valued items.
 		for _, name := range em.ValueToNames[val] {
 			delete(c.checkl, member{
 				em.NameToPos[name],
@@ -330,6 +408,17 @@ func (c *checklist) found(val constantValue) {
 	}
 }
 
+// restrictFlags removes the single-bit members of bit-flag enums whose
+// bit isn't in the mask, so that they need not be accounted for.
+func (c *checklist) restrictFlags(mask uint64) {
+	for m := range c.checkl {
+		em := c.info[m.typ]
+		if bit, ok := isFlagMember(em, m.name); em.Flags && ok && mask&bit == 0 {
+			delete(c.checkl, m)
+		}
+	}
+}
+
 func (c *checklist) remaining() map[member]struct{} {
 	return c.checkl
 }
@@ -356,7 +445,15 @@ func groupify(items map[member]struct{}, types []enumType) []group {
 		if typesOrder[x.typ] > typesOrder[y.typ] {
 			return false
 		}
-		return x.pos < y.pos
+		// Members without a position, such as those declared in
+		// files excluded from the build, come last, in name order.
+		if (x.pos == token.NoPos) != (y.pos == token.NoPos) {
+			return y.pos == token.NoPos
+		}
+		if x.pos != y.pos {
+			return x.pos < y.pos
+		}
+		return x.name < y.name
 	}
 
 	// byConstVal groups member names by constant value.
@@ -388,16 +485,31 @@ func groupify(items map[member]struct{}, types []enumType) []group {
 }
 
 func diagnosticEnumType(enumType *types.TypeName) string {
+	if t := enumType.Type(); t != nil {
+		if named, ok := t.(*types.Named); !ok || named.Obj() != enumType {
+			// The enum type of a variable enum declared on a var
+			// declaration; see varEnumsReferenced.
+			return types.TypeString(t, func(p *types.Package) string { return p.Name() })
+		}
+	}
 	return enumType.Pkg().Name() + "." + enumType.Name()
 }
 
 func diagnosticEnumTypes(types []enumType) string {
 	var buf strings.Builder
+	seen := make(map[string]bool)
 	for i := range types {
-		buf.WriteString(diagnosticEnumType(types[i].TypeName))
-		if i != len(types)-1 {
+		// The enum type of extension members is printed the same as the
+		// enum type itself; see extensionsOf.
+		s := diagnosticEnumType(types[i].TypeName)
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		if buf.Len() != 0 {
 			buf.WriteByte('|')
 		}
+		buf.WriteString(s)
 	}
 	return buf.String()
 }
@@ -407,6 +519,12 @@ func diagnosticMember(m member) string {
 }
 
 func diagnosticGroups(gs []group) string {
+	return strings.Join(diagnosticGroupNames(gs), ", ")
+}
+
+// diagnosticGroupNames returns the name of each group, which joins the
+// names of its members with "|".
+func diagnosticGroupNames(gs []group) []string {
 	out := make([]string, len(gs))
 	for i := range gs {
 		var buf strings.Builder
@@ -418,7 +536,21 @@ func diagnosticGroups(gs []group) string {
 		}
 		out[i] = buf.String()
 	}
-	return strings.Join(out, ", ")
+	return out
+}
+
+// missingRelated returns the related information of a diagnostic, for the
+// node, that lists missing members: an entry for each name in missing, in
+// order, whose message is the name. A name is that of a member, a group of
+// same-valued members, or a combination of members, as listed in the
+// diagnostic message. Drivers use the entries to obtain the missing
+// members without parsing the message.
+func missingRelated(node ast.Node, missing []string) []analysis.RelatedInformation {
+	out := make([]analysis.RelatedInformation, len(missing))
+	for i := range missing {
+		out[i] = analysis.RelatedInformation{Pos: node.Pos(), End: node.End(), Message: missing[i]}
+	}
+	return out
 }
 
 func toEnumTypes(es []enumTypeAndMembers) []enumType {
@@ -0,0 +1,183 @@
+package exhaustive
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// CheckAnalyzer checks a package in the same way as Analyzer, configured by
+// the same flags, and returns the outcomes of the checks of switch
+// statements and map literals as its result, of type []Check, so that
+// programs can report, for example, how often each enum type is checked
+// and how often its checks are suppressed. It reports no diagnostics.
+//
+// CheckAnalyzer exports the same facts as Analyzer, so the two cannot be
+// run by the same driver.
+var CheckAnalyzer = &analysis.Analyzer{
+	Name:       "exhaustivechecks",
+	Doc:        "record the outcomes of exhaustiveness checks in a package",
+	Run:        runCheckRecorder,
+	Requires:   Analyzer.Requires,
+	FactTypes:  Analyzer.FactTypes,
+	ResultType: reflect.TypeOf([]Check(nil)),
+}
+
+func runCheckRecorder(pass *analysis.Pass) (interface{}, error) {
+	p := *pass
+	p.Report = func(analysis.Diagnostic) {}
+	var recorder checkRecorder
+	runChecks(&p, &recorder)
+	return recorder.checks, nil
+}
+
+// A Check is the outcome of checking a switch statement or a map literal
+// whose switch tag or key type is composed of enum types, or whose key
+// type is a struct type with fields of enum types.
+type Check struct {
+	Node    CheckNode
+	Pos     token.Pos
+	Types   []*types.TypeName // the enum types
+	Outcome Outcome
+	Default bool // whether the switch statement has a default case
+}
+
+// CheckNode is a kind of program element that a Check is for.
+type CheckNode string
+
+const (
+	CheckNodeSwitch CheckNode = "switch" // switch statement
+	CheckNodeMap    CheckNode = "map"    // map literal
+)
+
+// Outcome is the outcome of a Check.
+type Outcome string
+
+const (
+	OutcomeExhaustive Outcome = "exhaustive" // the enum members are accounted for
+	OutcomeIgnored    Outcome = "ignored"    // checking was skipped due to an ignore directive
+	OutcomeSkipped    Outcome = "skipped"    // checking was skipped due to a missing enforce directive, in explicit mode
+	OutcomeDefault    Outcome = "default"    // the default case signified exhaustiveness
+	OutcomeFailing    Outcome = "failing"    // a diagnostic was reported
+)
+
+// checkOutcome returns the outcome that corresponds to the result
+// returned by a node visitor, if any.
+func checkOutcome(result string) (Outcome, bool) {
+	switch result {
+	case resultEnumMembersAccounted:
+		return OutcomeExhaustive, true
+	case resultIgnoreComment, resultUnnecessaryIgnore:
+		return OutcomeIgnored, true
+	case resultNoEnforceComment:
+		return OutcomeSkipped, true
+	case resultDefaultCaseSuffices:
+		return OutcomeDefault, true
+	case resultReportedDiagnostic, resultMissingDefaultCase:
+		return OutcomeFailing, true
+	}
+	return "", false
+}
+
+// checkRecorder records the outcomes of the node visitors.
+type checkRecorder struct {
+	checks []Check
+}
+
+// record returns a node visitor that calls v and records the outcome of
+// its check, for nodes whose enum types are determined by enumTypes. If r
+// is nil, it returns v.
+func (r *checkRecorder) record(node CheckNode, enumTypes func(ast.Node) []enumTypeAndMembers, v nodeVisitor) nodeVisitor {
+	if r == nil {
+		return v
+	}
+	return func(n ast.Node, push bool, stack []ast.Node) (bool, string) {
+		proceed, result := v(n, push, stack)
+		outcome, ok := checkOutcome(result)
+		if !push || !ok {
+			return proceed, result
+		}
+		var tns []*types.TypeName
+		seen := make(map[*types.TypeName]bool)
+		for _, e := range enumTypes(n) {
+			tn := e.typ.declared()
+			if !seen[tn] {
+				seen[tn] = true
+				tns = append(tns, tn)
+			}
+		}
+		if len(tns) != 0 {
+			sw, ok := n.(*ast.SwitchStmt)
+			r.checks = append(r.checks, Check{node, n.Pos(), tns, outcome, ok && hasDefaultCase(sw)})
+		}
+		return proceed, result
+	}
+}
+
+// switchEnumTypes returns the enum types of the switch statement's tag,
+// or of the operand of a tagless switch statement.
+func switchEnumTypes(pass *analysis.Pass) func(ast.Node) []enumTypeAndMembers {
+	return func(n ast.Node) []enumTypeAndMembers {
+		sw := n.(*ast.SwitchStmt)
+		tag := sw.Tag
+		if tag == nil {
+			operand, ok := taglessSwitchOperand(sw, pass.TypesInfo, true)
+			if !ok {
+				return nil
+			}
+			tag = operand
+		}
+		t := pass.TypesInfo.Types[tag]
+		if !t.IsValue() {
+			return nil
+		}
+		es, ok := composingEnumTypes(pass, t.Type)
+		if !ok || len(es) == 0 {
+			return varEnumsReferenced(pass, switchCaseValues(sw, pass.TypesInfo))
+		}
+		return es
+	}
+}
+
+// mapEnumTypes returns the enum types of the map literal's key type. For a
+// struct key type whose fields are all of enum types, which is checked
+// with the -check-product-keys flag, they are the enum types of the
+// fields.
+func mapEnumTypes(pass *analysis.Pass) func(ast.Node) []enumTypeAndMembers {
+	return func(n ast.Node) []enumTypeAndMembers {
+		mapType, ok := mapLiteralType(n.(*ast.CompositeLit), pass.TypesInfo)
+		if !ok {
+			return nil
+		}
+		es, ok := composingEnumTypes(pass, mapType.Key())
+		if ok && len(es) != 0 {
+			return es
+		}
+		st, ok := mapType.Key().Underlying().(*types.Struct)
+		if !ok {
+			return nil
+		}
+		var fieldTypes []enumTypeAndMembers
+		for i := 0; i < st.NumFields(); i++ {
+			es, ok := composingEnumTypes(pass, st.Field(i).Type())
+			if !ok || len(es) == 0 {
+				return nil
+			}
+			fieldTypes = append(fieldTypes, es...)
+		}
+		return fieldTypes
+	}
+}
+
+// hasDefaultCase reports whether the switch statement has a default case.
+func hasDefaultCase(sw *ast.SwitchStmt) bool {
+	for _, stmt := range sw.Body.List {
+		if isDefaultCase(stmt.(*ast.CaseClause)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package exhaustive
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestCheckResult(t *testing.T) {
+	resetFlags()
+	defer resetFlags()
+	fCheck = stringsFlag{[]string{string(elementSwitch), string(elementMap)}, nil}
+	fDefaultSignifiesExhaustive = true
+	fCheckProductKeys = true
+
+	results := analysistest.Run(t, analysistest.TestData(), CheckAnalyzer, "coverage")
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	fset := results[0].Pass.Fset
+
+	type checkSummary struct {
+		Node    CheckNode
+		Line    int
+		Types   string
+		Outcome Outcome
+		Default bool
+	}
+	var got []checkSummary
+	for _, c := range results[0].Result.([]Check) {
+		var names string
+		for i, tn := range c.Types {
+			if i > 0 {
+				names += ","
+			}
+			names += tn.Name()
+		}
+		got = append(got, checkSummary{c.Node, fset.Position(c.Pos).Line, names, c.Outcome, c.Default})
+	}
+
+	want := []checkSummary{
+		{CheckNodeSwitch, 20, "Direction", OutcomeExhaustive, false},
+		{CheckNodeSwitch, 25, "Direction", OutcomeIgnored, false},
+		{CheckNodeSwitch, 29, "Direction", OutcomeFailing, false},
+		{CheckNodeSwitch, 33, "Suit", OutcomeDefault, true},
+		{CheckNodeMap, 43, "Suit", OutcomeFailing, false},
+		{CheckNodeMap, 47, "Direction", OutcomeExhaustive, false},
+		{CheckNodeMap, 50, "Suit", OutcomeFailing, false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,138 @@
+package exhaustive
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// A deprecated member is an enum member whose doc comment has a paragraph
+// that begins with "Deprecated: ", as in:
+//
+//	const (
+//		StatusActive Status = iota
+//		// Deprecated: Use StatusActive instead.
+//		StatusEnabled
+//	)
+//
+// Unless the -require-deprecated flag is set, deprecated members need not
+// be listed to satisfy exhaustiveness outside the package of the enum
+// type.
+
+// deprecatedPrefix is the prefix of the paragraph of a doc comment that
+// marks the documented identifier as deprecated.
+const deprecatedPrefix = "Deprecated: "
+
+// deprecationNotice returns the text, following the prefix, of the
+// deprecation paragraph of the doc comment, which can be nil.
+func deprecationNotice(doc *ast.CommentGroup) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+	for _, p := range strings.Split(doc.Text(), "\n\n") {
+		if strings.HasPrefix(p, deprecatedPrefix) {
+			return strings.TrimPrefix(p, deprecatedPrefix), true
+		}
+	}
+	return "", false
+}
+
+// markDeprecated records, in em.Deprecated, that the named member is
+// deprecated with the notice. The replacement of the member is the first
+// other member named in the notice, such as StatusActive in
+// "Use StatusActive instead."; it is empty if the notice names none. It
+// must be called after all members have been added.
+func (em *enumMembers) markDeprecated(name, notice string) {
+	var replacement string
+	words := strings.FieldsFunc(notice, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.'
+	})
+	for _, w := range words {
+		// Allow package-qualified names, such as pkg.StatusActive,
+		// and names that end a sentence.
+		w = strings.TrimSuffix(w, ".")
+		w = w[strings.LastIndex(w, ".")+1:]
+		if _, ok := em.NameToValue[w]; ok && w != name {
+			replacement = w
+			break
+		}
+	}
+	if em.Deprecated == nil {
+		em.Deprecated = make(map[string]string)
+	}
+	em.Deprecated[name] = replacement
+}
+
+// reportDeprecated reports each of the expressions that refers to a
+// deprecated member of one of the enums declared in another package. If
+// the member has a replacement that the expressions don't refer to, the
+// diagnostic includes a suggested fix that replaces the expression with
+// the replacement.
+func reportDeprecated(pass *analysis.Pass, file *ast.File, exprs []ast.Expr, es []enumTypeAndMembers, report func(analysis.Diagnostic)) {
+	listed := make(map[constantValue]bool)
+	for _, e := range exprs {
+		if val, ok := exprConstVal(e, pass.TypesInfo); ok {
+			listed[val] = true
+		}
+	}
+
+	for _, e := range exprs {
+		obj, ok := referencedConst(e, pass.TypesInfo)
+		if !ok || obj.Pkg() == pass.Pkg {
+			continue
+		}
+		for _, et := range es {
+			if et.typ.Pkg() != obj.Pkg() {
+				continue
+			}
+			replacement, ok := et.members.Deprecated[obj.Name()]
+			if !ok {
+				continue
+			}
+			d := analysis.Diagnostic{
+				Pos:      e.Pos(),
+				End:      e.End(),
+				Category: CategoryDeprecatedMember,
+				Message: fmt.Sprintf(
+					"%s refers to deprecated enum member %s",
+					types.ExprString(e),
+					diagnosticMember(member{typ: et.typ, name: obj.Name()}),
+				),
+			}
+			m := member{et.members.NameToPos[replacement], et.typ, replacement, et.members.NameToValue[replacement]}
+			if expr, ok := memberExpr(file, pass.Pkg, m); ok && replacement != "" && !listed[m.val] {
+				d.SuggestedFixes = []analysis.SuggestedFix{{
+					Message: fmt.Sprintf("replace with %s", expr),
+					TextEdits: []analysis.TextEdit{{
+						Pos:     e.Pos(),
+						End:     e.End(),
+						NewText: []byte(expr),
+					}},
+				}}
+			}
+			report(d)
+			break
+		}
+	}
+}
+
+// referencedConst returns the constant that the expression, an identifier
+// or a qualified identifier, refers to.
+func referencedConst(e ast.Expr, info *types.Info) (*types.Const, bool) {
+	var ident *ast.Ident
+	switch e := astutil.Unparen(e).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return nil, false
+	}
+	obj, ok := info.Uses[ident].(*types.Const)
+	return obj, ok
+}
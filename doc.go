@@ -32,6 +32,22 @@ the type. The constant values may be specified using iota, literal values, or
 any valid means for declaring a Go constant. It is allowed for multiple enum
 member constants for an enum type to have the same constant value.
 
+If the -enum-discovery flag is set to "annotated", only types associated
+with an "//exhaustive:enum" comment are enum types. In this mode, a
+constant of such a type that is declared outside the type's block, and
+hence is not an enum member, is reported, unless it is registered as an
+extension member (see the Extension members section).
+
+	//exhaustive:enum
+	type Biome int
+
+To see which types are enum types, and their members, use the enums
+subcommand of the exhaustive command, which accepts the same flags:
+
+	exhaustive enums ./...
+
+InventoryAnalyzer provides the same information to other programs.
+
 # Definition of exhaustiveness
 
 A switch statement that switches on a value of an enum type is exhaustive if
@@ -48,6 +64,37 @@ satisfying exhaustiveness; other expressions, such as literal values and
 function calls, listed in case clauses do not contribute towards satisfying
 exhaustiveness.
 
+Trailing enum members that are sentinels, such as numKinds below, need not
+be listed to satisfy exhaustiveness. A trailing member is a sentinel if its
+name matches the -sentinel-pattern flag and its value is the number of
+preceding members, the largest value of the preceding members, or one
+more than that value. The -sentinel-pattern flag is empty by default, so
+that no member is a sentinel unless the flag is set; for example,
+-sentinel-pattern='^num[A-Z]' treats numKinds below as a sentinel.
+
+	const (
+		KindA Kind = iota
+		KindB
+		numKinds
+	)
+
+If the -match-values flag is set, any constant-valued expression listed in
+a switch statement's case clause, or as a map literal key, such as 3 or
+Tundra + 1, contributes towards satisfying exhaustiveness for the enum
+members with the same value. If the -report-member-values flag is set,
+such an expression is reported, with a suggested fix that replaces it with
+the name of the enum member.
+
+A tagless switch statement is checked like a switch statement that
+switches on a value of an enum type if each of its case expressions is an
+equality comparison, or a disjunction of equality comparisons, between the
+same variable and enum members, as in:
+
+	switch {
+	case b == Tundra:
+	case b == Savanna || b == Desert:
+	}
+
 By default, the existence of a default case in a switch statement does not
 unconditionally make a switch statement exhaustive. Use the
 -default-signifies-exhaustive flag to adjust this behavior.
@@ -57,6 +104,287 @@ exhaustiveness applies. The map literal is considered exhaustive if all enum
 members are be listed in its keys. Empty map literals are never checked for
 exhaustiveness.
 
+If "map" is included in the -check flag, map variables that are
+populated by index assignments, rather than by a map literal, are checked
+if their declaration is associated with a "//exhaustive:enforce" comment.
+Such a map variable is exhaustive if there is an index assignment for
+each enum member. For a variable declared in a function, the index
+assignments in the function are considered. For a package-level
+variable, the index assignments in the package's init functions are
+considered. For example:
+
+	//exhaustive:enforce
+	var names = make(map[Biome]string)
+
+	func init() {
+		names[Tundra] = "tundra"
+		names[Savanna] = "savanna"
+		names[Desert] = "desert"
+	}
+
+The initial value of the variable, if any, must be a call to make or an
+empty map literal.
+
+If "array" is included in the -check flag, array and slice literals
+indexed by an enum type are checked similarly. Such a literal is
+exhaustive if there is an element at the index of each enum member. The
+index of an unkeyed element is the index of the preceding element plus
+one, or zero for the first element. A literal is indexed by an enum type
+if the keys of its keyed elements are of the enum type, as in
+[...]string{Tundra: "tundra", Savanna: "savanna"}, or, if it has no keyed
+elements, if its array length is of the enum type, as in
+[biomeCount]string{"tundra", "savanna"}. Enum members whose values are
+not valid indexes into the literal, such as a trailing biomeCount member
+that is used as the array length, do not have to be listed.
+
+If "if" is included in the -check flag, if-else chains that compare an
+enum value against enum members are checked similarly to switch
+statements. An if-else chain is checked if it has at least one "else if"
+and each of its conditions is an equality comparison, or a disjunction of
+equality comparisons, between the same variable and enum members, as in:
+
+	if b == Tundra {
+	} else if b == Savanna || b == Desert {
+	}
+
+The final else, if any, is treated like a default case in a switch
+statement. The -explicit-exhaustive-switch and
+-default-signifies-exhaustive flags apply to if-else chains too.
+
+If the -check-product-keys flag is set, a map literal whose key type is a
+struct type with fields of enum types, or a map literal of nested maps
+with enum key types, is exhaustive if its keys cover each combination of
+enum members, as in:
+
+	var transitions = map[State]map[Event]State{
+		Idle:    {Start: Running, Stop: Idle},
+		Running: {Start: Running, Stop: Idle},
+	}
+
+Missing combinations are reported compactly; for example, "(Running, *)"
+stands for each combination whose first member is Running. Combinations
+that are intentionally absent can be listed in a "//exhaustive:exclude"
+comment associated with the map literal, with "*" matching any member:
+
+	//exhaustive:exclude (Idle, Stop) (Stopped, *)
+	var transitions = map[Transition]bool{...}
+
+A map literal is checked in this manner only if each of its keys, at each
+level, is an enum member constant, and for nested maps, if each inner map
+is a map literal.
+
+# Variable enums
+
+Sets of package-level variables, such as sentinel errors, can be declared
+as enums by associating a "//exhaustive:enum" comment with either a var
+declaration or a type declaration. In the first form, the enum members are
+the variables declared by the var declaration:
+
+	//exhaustive:enum
+	var (
+		ErrNotFound = errors.New("not found")
+		ErrTimeout  = errors.New("timeout")
+	)
+
+In the second form, the enum members are the package-level variables, in
+the same package, whose type is the named type or the pointer to the named
+type. A variable declared with an "//exhaustive:ignore" comment is not a
+member. A named type that is already an enum type by virtue of its
+constants is not affected by the comment.
+
+	//exhaustive:enum
+	type Color struct{ name string }
+
+	var (
+		Red   = &Color{"red"}
+		Green = &Color{"green"}
+	)
+
+A switch statement, or an if-else chain, that lists members of a variable
+enum is checked for exhaustiveness as described above. Members are matched
+by the variable they refer to, not by value.
+
+# Deprecated members
+
+An enum member whose doc comment has a paragraph that begins with
+"Deprecated: " is deprecated. Deprecated members need not be listed to
+satisfy exhaustiveness outside the package of the enum type, unless the
+-require-deprecated flag is set. Within the package of the enum type,
+they are required like other members.
+
+	const (
+		StatusActive Status = iota
+		// Deprecated: Use StatusActive instead.
+		StatusEnabled
+	)
+
+With the -report-deprecated flag, a case expression in a switch statement
+that refers to a deprecated member declared in another package is
+reported. If the deprecation paragraph names another member of the enum
+type, such as StatusActive above, the diagnostic includes a suggested fix
+that replaces the case expression with that member.
+
+# Build constraints
+
+The members of an enum type can be declared in files with different build
+constraints, such as kind_linux.go and kind_windows.go. By default, only
+the members declared in the files of the current build configuration are
+discovered. With the -build-constraints flag, the members declared in the
+package's files that are excluded from the current build configuration
+are discovered too, so that the members of the enum type are the union of
+its members across build configurations. The build constraint of the file
+that declares each member is recorded along with the member, and a switch
+statement has to list only the members whose build constraints are implied
+by the build constraint of its own file. The build constraint of a file
+is the conjunction of its //go:build line and the constraint implied by its
+name, such as linux for kind_linux.go.
+
+For example, a member declared in kind_linux.go need not be listed in a
+switch statement in a file without build constraints, where listing it
+would fail to compile for other operating systems, but has to be listed
+in a switch statement in a file such as poll_linux.go.
+
+Excluded files are parsed but not type-checked. A constant declared in
+one is a member if its declaration names the enum type, explicitly or
+implicitly through a preceding specification in a parenthesized
+declaration, and if its value can be computed from the declarations in the
+current build configuration. Switch statements in excluded files are not
+checked; to check them, run the analyzer for their build configuration,
+for example with a different GOOS value.
+
+# Named groups
+
+A named subset of the members of an enum type can be declared by
+associating one or more "//exhaustive:group" comments, each giving the
+name of a group and a comma-separated list of its members, with the
+type's declaration:
+
+	//exhaustive:group terminal Done,Failed,Cancelled
+	type State int
+
+A switch statement associated with an "//exhaustive:enforce group=terminal"
+comment is exhaustive if it lists each member of the group; the other
+members need not be listed. A case expression that has the value of a
+member outside the group is reported, since it is likely a mistake.
+
+	//exhaustive:enforce group=terminal
+	switch s {
+	case Done, Failed, Cancelled:
+	}
+
+Groups are recorded along with the enum members, so that they can be
+used in other packages.
+
+# Extension members
+
+A constant declared in a package other than that of its enum type can be
+registered as a member of the enum type, as an extension member, by
+associating a "//exhaustive:member-of" comment with its declaration. The
+comment names the enum type, qualified by the name the file imports its
+package with. Only constants declared at package scope can be extension
+members.
+
+	package plugin
+
+	//exhaustive:member-of core.Kind
+	const Custom core.Kind = 100
+
+Extension members have to be listed to satisfy exhaustiveness in the
+package that declares them and in the packages that depend on it, such as
+the package that assembles the plugins of a program. Elsewhere, including
+in the package of the enum type, only the enum type's own members are
+required. The comment is intended for packages in the same module as the
+enum type; this is not enforced.
+
+# Bit-flag enums
+
+An enum whose members are bit flags can be declared a bit-flag enum by
+associating an "//exhaustive:flags" comment with its type. Enum types
+whose members merely look like bit flags are not treated as bit-flag
+enums without the comment.
+
+	//exhaustive:flags
+	type Style uint8
+
+	const (
+		Bold Style = 1 << iota
+		Italic
+		Underline
+		All = Bold | Italic | Underline
+	)
+
+Only the single-bit members of a bit-flag enum have to be listed to
+satisfy exhaustiveness, and a constant expression that combines members,
+such as Bold|Italic, lists each of the members it combines. A switch
+statement whose tag masks out bits, such as "switch s & (Bold|Italic)",
+does not have to list the members outside the mask.
+
+An if-else chain, or a tagless switch statement, whose conditions are bit
+tests of the same bit-flag enum value is exhaustive if each single-bit
+member is tested. The supported forms of bit test are s&M != 0, s&M == M,
+and s&M > 0, where M is a member or a combination of members.
+
+	if s&Bold != 0 {
+	} else if s&(Italic|Underline) != 0 {
+	}
+
+# Sealed interfaces
+
+An interface type declared at package scope is sealed if it has an
+unexported method, which prevents types in other packages from
+implementing it, or if it is associated with a "//exhaustive:sealed"
+comment. The members of a sealed interface are the non-interface types
+declared at package scope in the same package that implement the
+interface, either directly or through a pointer. In the example below,
+Shape is a sealed interface and its members are Circle and *Square.
+
+	package geom
+
+	type Shape interface{ isShape() }
+
+	type Circle struct{}
+	type Square struct{}
+
+	func (Circle) isShape()  {}
+	func (*Square) isShape() {}
+
+If "typeswitch" is included in the -check flag, type switch statements
+over a sealed interface are checked for exhaustiveness. A type switch
+statement is exhaustive if each member is listed in its cases. A member
+is listed if a case lists the member's type or an interface type that the
+member implements. In the example above, a case listing *Circle doesn't
+list Circle, since a Shape can hold values of both types. As with enum
+members, unexported members declared in an external package do not have
+to be listed. The -explicit-exhaustive-switch,
+-default-signifies-exhaustive, and -ignore-enum-types flags apply to type
+switch statements too.
+
+# Protocol buffers
+
+With the -protobuf flag, the analyzer recognizes the enum types and the
+oneof interfaces generated by protoc-gen-go. A protobuf enum type is
+recognized by the T_name and T_value maps declared alongside the type,
+and by its protoreflect.Enum methods. The zero member of a protobuf enum
+whose name ends in "_UNSPECIFIED", such as Color_COLOR_UNSPECIFIED, need
+not be listed to satisfy exhaustiveness, nor need the placeholder members
+whose names end in "RESERVED", optionally followed by a number, such as
+Color_COLOR_RESERVED_4. A member such as Status_STATUS_RESERVED_FOR_ADMIN
+is a real member.
+Values reserved by a reserved statement in the .proto file have no
+members, so they never have to be listed. Deprecated values are marked as
+deprecated by protoc-gen-go, so they need not be listed outside the
+package of the enum type; see the Deprecated members section.
+
+The interface of the wrapper types of a oneof field, such as
+isEvent_Payload, is a sealed interface. With the -protobuf flag, type
+switch statements over oneof interfaces are checked even if type switch
+statements aren't included in the -check flag.
+
+	switch e.GetPayload().(type) {
+	case *pb.Event_Click:
+	case *pb.Event_Key:
+	}
+
 # Type parameters
 
 A switch statement that switches on a value whose type is a type parameter is
@@ -134,19 +462,35 @@ Summary:
 	-check                         comma-separated strings  switch
 	-explicit-exhaustive-switch    bool                     false
 	-explicit-exhaustive-map       bool                     false
+	-explicit-exhaustive-array     bool                     false
 	-check-generated               bool                     false
 	-default-signifies-exhaustive  bool                     false
 	-ignore-enum-members           regexp pattern           (none)
 	-ignore-enum-types             regexp pattern           (none)
 	-package-scope-only            bool                     false
+	-fix-case-body                 template                 (none)
+	-fix-default-body              template                 (none)
+	-fix-map-value                 string                   zero
+	-report-unnecessary-ignore     bool                     false
+	-check-product-keys            bool                     false
+	-match-values                  bool                     false
+	-report-member-values          bool                     false
+	-sentinel-pattern              regexp pattern           (none)
+	-enum-discovery                string                   all
+	-protobuf                      bool                     false
+	-require-deprecated            bool                     false
+	-report-deprecated             bool                     false
+	-build-constraints             bool                     false
 
 Descriptions:
 
 	-check
 		Comma-separated list of program elements to check for
 		exhaustiveness.  Supported program element values are
-		"switch" and "map". The default value is "switch", which
-		means that only switch statements are checked.
+		"switch", "map", "typeswitch" (see the Sealed interfaces
+		section), "array", and "if". The default value is
+		"switch", which means that only switch statements are
+		checked.
 
 	-explicit-exhaustive-switch
 		Check a switch statement only if it is associated with a
@@ -157,6 +501,10 @@ Descriptions:
 	-explicit-exhaustive-map
 		Similar to -explicit-exhaustive-switch but for map literals.
 
+	-explicit-exhaustive-array
+		Similar to -explicit-exhaustive-switch but for array and
+		slice literals.
+
 	-check-generated
 		Check generated files. For the definition of a generated
 		file, see https://golang.org/s/generatedcode.
@@ -188,6 +536,187 @@ Descriptions:
 		default, the analyzer discovers enums defined in all
 		blocks.
 
+	-fix-case-body
+		Body of the case clauses added by suggested fixes for
+		switch statements, as a [text/template] that expands to Go
+		statements. See the Suggested fixes section. By default
+		the case clauses have empty bodies.
+
+	-fix-default-body
+		Body of the default case clause added by suggested fixes
+		for switch statements that are missing a required default
+		case, as a [text/template] that expands to Go statements.
+		See the Suggested fixes section. By default the default
+		case clause has an empty body.
+
+	-fix-map-value
+		Value of the map literal elements added by suggested
+		fixes. Supported values are "zero" (the zero value of the
+		element type), "name" (the enum member name as a string),
+		"lower" (the name in lower case), and "snake" (the name in
+		snake case, e.g. "http_status" for HTTPStatus). Values
+		other than "zero" apply only if the element type's
+		underlying type is string; otherwise the zero value is
+		used. The default value is "zero".
+
+	-report-unnecessary-ignore
+		Report "//exhaustive:ignore" comments associated with
+		switch statements and map, array, and slice literals that
+		would be exhaustive without the comment. See the Skip analysis
+		section.
+
+	-check-product-keys
+		Check map literals with struct keys whose fields are of enum
+		types, and map literals of nested maps with enum key types,
+		for each combination of enum members. See the Definition of
+		exhaustiveness section.
+
+	-match-values
+		Count constant-valued expressions other than constant
+		identifiers, such as literal values, listed in switch
+		statement cases and map literal keys towards satisfying
+		exhaustiveness. See the Definition of exhaustiveness section.
+
+	-report-member-values
+		Report constant-valued expressions other than constant
+		identifiers, listed in switch statement cases and map
+		literal keys, that have the value of an enum member, with a
+		suggested fix that replaces each with the name of the
+		member. See the Definition of exhaustiveness section.
+
+	-sentinel-pattern
+		Trailing enum members whose names match the specified
+		regular expression, and whose values are consistent with a
+		count or maximum of the preceding members, are sentinels and
+		do not have to be listed to satisfy exhaustiveness. The
+		regular expression is matched against the member name
+		alone. By default, the value is empty and no enum member is
+		a sentinel. For example, the value
+		`^_?([nN]um|[mM]ax|[cC]ount)([A-Z_]|$)|Count$` matches names
+		such as NumKinds, maxKind, and KindCount.
+
+	-enum-discovery
+		Types that are considered enum types. Supported values are
+		"all" and "annotated". With "all", every type that meets the
+		definition of an enum type is an enum type. With
+		"annotated", only such types that are associated with an
+		"//exhaustive:enum" comment are enum types. See the
+		Definition of enum section.
+
+	-protobuf
+		Recognize the enum types and oneof interfaces generated by
+		protoc-gen-go. The zero "_UNSPECIFIED" member and the
+		"RESERVED" placeholder members of a protobuf enum need not
+		be listed, and type switch statements over oneof interfaces
+		are checked. See the Protocol buffers section.
+
+	-require-deprecated
+		Require enum members documented as deprecated to be listed
+		in packages other than the package of the enum type. See
+		the Deprecated members section.
+
+	-report-deprecated
+		Report switch statement case expressions that refer to
+		deprecated enum members declared in other packages. See
+		the Deprecated members section.
+
+	-build-constraints
+		Discover enum members in files excluded from the current
+		build configuration, record the build constraints of the
+		files that declare enum members, and require a switch
+		statement to list only the members whose build constraints
+		are implied by those of its file. See the Build constraints
+		section.
+
+# Output formats
+
+Each diagnostic has a category that identifies its kind, such as
+"missing-cases", "missing-keys", "missing-default", or
+"invalid-directive"; the categories are listed by the Category constants.
+The exhaustive command prints diagnostics as plain text by default. Its
+-format flag selects SARIF ("sarif"), Checkstyle XML ("checkstyle"),
+GitHub Actions annotations ("github"), or JUnit XML ("junit") output
+instead, in which the category is the rule ID. See the documentation of
+the command for details. A diagnostic of the "missing-cases",
+"missing-keys", or "missing-elements" category has related information
+for each missing member, group of same-valued members, or combination of
+members, in the order of the message, whose message is its name.
+
+CheckAnalyzer, which checks a package in the same way as Analyzer but
+reports no diagnostics, lists the outcome of checking each switch
+statement and map literal whose tag or key type is an enum type, or whose
+key type is a struct type with fields of enum types: whether
+it is exhaustive, ignored, skipped in explicit mode, made exhaustive by a
+default case, or failing; see Check. The coverage subcommand of the
+exhaustive command aggregates these outcomes for each enum type across
+packages, as JSON or as an HTML summary:
+
+	exhaustive coverage -format=html ./... > coverage.html
+
+Similarly, the impact subcommand lists the switch statements and map
+literals that would break if a member were added to an enum type:
+
+	exhaustive impact example.org/eco.Biome ./...
+
+# Suggested fixes
+
+A diagnostic for a switch statement with missing cases includes a
+suggested fix that adds a case clause for each missing enum member (or
+for one of each set of same-valued members), in the order the members are
+declared. The members are referred to using the name that the file
+imports their package with. No fix is suggested if the file doesn't
+import the package. Fixes can be applied with "exhaustive -fix" or by an
+editor using gopls.
+
+The body of the added case clauses is specified by the -fix-case-body
+flag. The template is executed with the following data:
+
+	.Member  the enum member listed in the case clause, e.g. "token.Add"
+	.Type    the enum type of the switch tag, e.g. "token.Token"
+	.Tag     an expression that refers to the switch tag
+
+For example:
+
+	exhaustive -fix -fix-case-body 'panic("TODO: handle {{.Member}}")'
+
+A diagnostic for a switch statement that is missing a required default
+case (due to the -default-case-required flag or the
+"//exhaustive:enforce-default-case-required" directive) includes a suggested fix that adds a
+default case clause. Its body is specified by the -fix-default-body flag.
+The template is executed with the same data, except that .Member is
+empty. For example:
+
+	exhaustive -fix -fix-default-body 'panic(fmt.Sprintf("unexpected %T: %v", {{.Tag}}, {{.Tag}}))'
+
+If the template refers to .Tag and evaluating the switch tag may have
+side effects (for example, the tag is a function call), the fix binds the
+tag to a new variable in the switch statement's init statement and .Tag
+refers to the variable. No fix is suggested if the switch statement
+already has an init statement. The operand of a tagless switch statement
+can't be bound this way; if it may have side effects, no fix is
+suggested, and a diagnostic of the "fix-unavailable" category reports the
+operand. If the expanded statements refer to a package that the file
+doesn't import, the fix adds an import for the package. The package is looked up by name among the packages that the
+file imports under another name, and otherwise among commonly used
+standard library packages, such as fmt, errors, and strconv. If the
+package is not found, no fix is suggested, and a diagnostic of the
+"fix-unavailable" category reports the package; import the package in the
+file to have the fix suggested.
+
+Similarly, a diagnostic for a map literal with missing keys includes a
+suggested fix that adds an element for each missing enum member. The
+elements are added one per line if the map literal's existing elements
+span multiple lines, and inline otherwise. The value of the added
+elements is specified by the -fix-map-value flag.
+
+A diagnostic for a type switch statement over a sealed interface includes
+a suggested fix that adds a case clause for each missing member. The body
+of the added case clauses is specified by the -fix-case-body flag, where
+.Member is the member's type, .Type is the interface type, and .Tag
+refers to the variable declared by the type switch guard. If the guard
+doesn't declare a variable and the template refers to .Tag, the fix
+declares one.
+
 # Skip analysis
 
 To skip analysis of a switch statement or a map literal, associate it with a
@@ -199,6 +728,22 @@ comment that begins with "//exhaustive:ignore". For example:
 	case B:
 	}
 
+Comments that are likely intended to be directives but are not
+correctly written, such as "// exhaustive:ignore" (note the space after
+"//"), "//exhaustive: ignore", and "//exhaustive:ignroe", are reported,
+along with a suggested fix that corrects the directive. Directives that
+apply only to declarations, such as "//exhaustive:enum",
+"//exhaustive:flags", "//exhaustive:sealed", "//exhaustive:member-of",
+and "//exhaustive:group", are reported as invalid when associated with a
+switch statement, map literal, or other checked program element, as is
+"//exhaustive:exclude" anywhere other than on a map literal.
+
+An ignore comment can become stale, for example after the missing enum
+members are listed. If the -report-unnecessary-ignore flag is set, ignore
+comments associated with switch statements and map literals that would
+be exhaustive without the comment are reported, along with a suggested
+fix that removes the comment.
+
 To ignore specific constants in exhaustiveness checks, specify the
 -ignore-enum-members flag:
 
@@ -3,6 +3,7 @@ package exhaustive
 import (
 	"fmt"
 	"go/ast"
+	"go/build/constraint"
 	"go/token"
 	"go/types"
 	"strings"
@@ -22,6 +23,16 @@ func (et enumType) String() string           { return et.TypeName.String() } //
 func (et enumType) scope() *types.Scope      { return et.TypeName.Parent() } // scope that the type is declared in
 func (et enumType) factObject() types.Object { return et.TypeName }          // types.Object for fact export
 
+// declared returns the type name that the enum type is declared with. It
+// differs from et.TypeName for the enum type of extension members; see
+// extensionsOf.
+func (et enumType) declared() *types.TypeName {
+	if named, ok := et.Type().(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Name() == et.Name() {
+		return named.Obj()
+	}
+	return et.TypeName
+}
+
 // enumMembers is the set of enum members for a single enum type.
 // The zero value is ready to use.
 type enumMembers struct {
@@ -29,6 +40,12 @@ type enumMembers struct {
 	NameToPos    map[string]token.Pos       // enum member name -> AST position
 	NameToValue  map[string]constantValue   // enum member name -> constant value
 	ValueToNames map[constantValue][]string // constant value -> enum member names
+	Flags        bool                       // whether the enum is a bit-flag enum; see flags.go
+	Sentinels    map[string]bool            // enum member name -> whether it is a sentinel; see markSentinels
+	Optional     map[string]bool            // enum member name -> whether it need not be listed; see markProtoOptional
+	Groups       map[string][]string        // group name -> enum member names; see findGroups
+	Deprecated   map[string]string          // deprecated enum member name -> replacement enum member name, possibly empty; see markDeprecated
+	Constraints  map[string]string          // enum member name -> build constraint of its file, if any; see recordConstraints
 }
 
 // add adds an enum member to the set.
@@ -68,6 +85,7 @@ func findEnums(pass *analysis.Pass, pkgScopeOnly bool, pkg *types.Package, inspe
 	result := make(map[enumType]enumMembers)
 
 	ignoredTypes := findIgnoredTypes(pass, inspect, info)
+	notices := make(map[enumType]map[string]string) // deprecation notices of members
 
 	inspect.Preorder([]ast.Node{&ast.GenDecl{}}, func(n ast.Node) {
 		gen := n.(*ast.GenDecl)
@@ -84,6 +102,10 @@ func findEnums(pass *analysis.Pass, pkgScopeOnly bool, pkg *types.Package, inspe
 			if hasIgnoreDecl(pass, s.Doc) {
 				continue
 			}
+			notice, deprecated := deprecationNotice(s.Doc)
+			if !deprecated && !gen.Lparen.IsValid() {
+				notice, deprecated = deprecationNotice(gen.Doc)
+			}
 
 			for _, name := range s.Names {
 
@@ -101,13 +123,55 @@ func findEnums(pass *analysis.Pass, pkgScopeOnly bool, pkg *types.Package, inspe
 				v := result[enumTyp]
 				v.add(memberName, val, name.Pos())
 				result[enumTyp] = v
+				if deprecated {
+					if notices[enumTyp] == nil {
+						notices[enumTyp] = make(map[string]string)
+					}
+					notices[enumTyp][memberName] = notice
+				}
 			}
 		}
 	})
 
+	flagTypes := findDirectiveTypes(inspect, info, flagsDirective)
+	for typ, members := range result {
+		_, annotated := flagTypes[typ.TypeName.Type()]
+		members.Flags = annotated
+		for name, notice := range notices[typ] {
+			members.markDeprecated(name, notice)
+		}
+		result[typ] = members
+	}
+
 	return result
 }
 
+// discoverEnums returns the enums declared in the package, with their
+// members marked as specified by the flags.
+func discoverEnums(pass *analysis.Pass, inspect *inspector.Inspector) map[enumType]enumMembers {
+	enums := findEnums(pass, fPackageScopeOnly, pass.Pkg, inspect, pass.TypesInfo)
+	if fEnumDiscovery.value == enumDiscoveryAnnotated {
+		enums = annotatedEnums(enums, findDirectiveTypes(inspect, pass.TypesInfo, enumDirective))
+	}
+	findGroups(pass, inspect, enums)
+	var constraints map[string]constraint.Expr
+	if fBuildConstraints {
+		constraints = fileConstraints(pass.Fset, pass.Files)
+		addExcludedMembers(pass, enums)
+	}
+	for typ, members := range enums {
+		members.markSentinels(fSentinelPattern.re)
+		if fProtobuf && isProtoEnum(typ) {
+			members.markProtoOptional(typ.Name())
+		}
+		if fBuildConstraints {
+			members.recordConstraints(pass.Fset, constraints)
+		}
+		enums[typ] = members
+	}
+	return enums
+}
+
 func possibleEnumMember(constName *ast.Ident, info *types.Info) (et enumType, name string, val constantValue, ok bool) {
 	// Notes
 	//
@@ -184,6 +248,90 @@ func findIgnoredTypes(pass *analysis.Pass, inspect *inspector.Inspector, info *t
 	return ignoredTypes
 }
 
+// findDirectiveTypes returns the types declared with the directive.
+func findDirectiveTypes(inspect *inspector.Inspector, info *types.Info, d directive) map[types.Type]struct{} {
+	result := make(map[types.Type]struct{})
+
+	inspect.Preorder([]ast.Node{&ast.GenDecl{}}, func(n ast.Node) {
+		gen := n.(*ast.GenDecl)
+		if gen.Tok != token.TYPE {
+			return
+		}
+		for _, s := range gen.Specs {
+			t := s.(*ast.TypeSpec)
+			// Invalid directives, if any, are reported during enum
+			// discovery; see hasIgnoreDecl.
+			dirs, _ := parseDirectives([]*ast.CommentGroup{gen.Doc, t.Doc})
+			if !dirs.has(d) {
+				continue
+			}
+			result[info.Defs[t.Name].Type()] = struct{}{}
+		}
+	})
+
+	return result
+}
+
+// Values for the -enum-discovery flag.
+const (
+	enumDiscoveryAll       = "all"       // every type that meets the definition of an enum type
+	enumDiscoveryAnnotated = "annotated" // only types declared with an "//exhaustive:enum" comment
+)
+
+var enumDiscoveryChoices = []string{enumDiscoveryAll, enumDiscoveryAnnotated}
+
+// annotatedEnums returns the enums, among those found by findEnums, whose
+// types are annotated.
+func annotatedEnums(enums map[enumType]enumMembers, annotated map[types.Type]struct{}) map[enumType]enumMembers {
+	result := make(map[enumType]enumMembers)
+	for typ, members := range enums {
+		if _, ok := annotated[typ.TypeName.Type()]; ok {
+			result[typ] = members
+		}
+	}
+	return result
+}
+
+// reportStrayMembers reports the constants of annotated enum types that
+// are declared outside the scope of the type, and hence are not enum
+// members. The annotated types declared in other packages are those with
+// an enum fact. The extension members, as returned by
+// findExtensionMembers, are enum members.
+func reportStrayMembers(pass *analysis.Pass, inspect *inspector.Inspector, annotated map[types.Type]struct{}, ext map[*types.TypeName]enumMembers) {
+	inspect.Preorder([]ast.Node{&ast.ValueSpec{}}, func(n ast.Node) {
+		for _, name := range n.(*ast.ValueSpec).Names {
+			obj, ok := pass.TypesInfo.Defs[name].(*types.Const)
+			if !ok || isBlankIdentifier(obj.Name()) || !validNamedBasic(obj.Type()) {
+				continue
+			}
+			tn := obj.Type().(*types.Named).Obj()
+			if tn.Parent() == obj.Parent() {
+				continue
+			}
+			if tn.Pkg() == pass.Pkg {
+				if _, ok := annotated[tn.Type()]; !ok {
+					continue
+				}
+			} else if _, ok := importFact(pass, enumType{tn}); !ok {
+				continue
+			}
+			if _, ok := ext[tn].NameToPos[obj.Name()]; ok {
+				continue
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:      name.Pos(),
+				End:      name.End(),
+				Category: CategoryStrayMember,
+				Message: fmt.Sprintf(
+					"constant %s of enum type %s is declared outside the scope of the type and is not an enum member",
+					obj.Name(),
+					diagnosticEnumType(tn),
+				),
+			})
+		}
+	})
+}
+
 func determineConstVal(name *ast.Ident, info *types.Info) constantValue {
 	c := info.ObjectOf(name).(*types.Const)
 	return constantValue(c.Val().ExactString())
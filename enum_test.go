@@ -144,6 +144,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 			map[constantValue][]string{
 				`1`: {"VCMixedB"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"IotaEnum", enumMembers{
 			[]string{"IotaA", "IotaB"},
@@ -159,6 +165,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				`0`: {"IotaA"},
 				`2`: {"IotaB"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"RepeatedValue", enumMembers{
 			[]string{"RepeatedValueA", "RepeatedValueB"},
@@ -173,6 +185,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 			map[constantValue][]string{
 				`1`: {"RepeatedValueA", "RepeatedValueB"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"AcrossBlocksDeclsFiles", enumMembers{
 			[]string{"Here", "Separate", "There"},
@@ -191,6 +209,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				`1`: {"Separate"},
 				`2`: {"There"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"UnexportedMembers", enumMembers{
 			[]string{"unexportedMembersA", "unexportedMembersB"},
@@ -206,6 +230,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				`1`: {"unexportedMembersA"},
 				`2`: {"unexportedMembersB"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"ParenVal", enumMembers{
 			[]string{"ParenVal0", "ParenVal1"},
@@ -221,6 +251,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				`0`: {"ParenVal0"},
 				`1`: {"ParenVal1"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"EnumRHS", enumMembers{
 			[]string{"EnumRHS_A", "EnumRHS_B"},
@@ -236,6 +272,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				`0`: {"EnumRHS_A"},
 				`1`: {"EnumRHS_B"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"WithMethod", enumMembers{
 			[]string{"WithMethodA", "WithMethodB"},
@@ -251,6 +293,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				`1`: {"WithMethodA"},
 				`2`: {"WithMethodB"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"T", enumMembers{
 			[]string{"A", "B"},
@@ -266,6 +314,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				`0`: {"A"},
 				`1`: {"B"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"PkgRequireSameLevel", enumMembers{
 			[]string{"PA"},
@@ -278,6 +332,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 			map[constantValue][]string{
 				`200`: {"PA"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"UIntEnum", enumMembers{
 			[]string{"UIntA", "UIntB"},
@@ -293,6 +353,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				"0": {"UIntA"},
 				"1": {"UIntB"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"StringEnum", enumMembers{
 			[]string{"StringA", "StringB", "StringC"},
@@ -311,6 +377,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				`"stringb"`: {"StringB"},
 				`"stringc"`: {"StringC"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"RuneEnum", enumMembers{
 			[]string{"RuneA"},
@@ -323,6 +395,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 			map[constantValue][]string{
 				`97`: {"RuneA"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"ByteEnum", enumMembers{
 			[]string{"ByteA"},
@@ -335,6 +413,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 			map[constantValue][]string{
 				`97`: {"ByteA"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"Int32Enum", enumMembers{
 			[]string{"Int32A", "Int32B"},
@@ -350,6 +434,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				"0": {"Int32A"},
 				"1": {"Int32B"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"Float64Enum", enumMembers{
 			[]string{"Float64A", "Float64B"},
@@ -365,6 +455,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				`0`: {"Float64A"},
 				`1`: {"Float64B"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"DeclGroupIgnoredEnum", enumMembers{
 			[]string{"DeclGroupIgnoredMemberC"},
@@ -377,6 +473,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 			map[constantValue][]string{
 				`3`: {"DeclGroupIgnoredMemberC"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"DeclIgnoredEnum", enumMembers{
 			[]string{"DeclIgnoredMemberB"},
@@ -389,6 +491,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 			map[constantValue][]string{
 				`2`: {"DeclIgnoredMemberB"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"DeclTypeInnerNotIgnore", enumMembers{
 			[]string{"DeclTypeInnerNotIgnoreMember"},
@@ -401,6 +509,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 			map[constantValue][]string{
 				`5`: {"DeclTypeInnerNotIgnoreMember"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"DeclTypeIgnoredValue", enumMembers{
 			[]string{"DeclTypeNotIgnoredValue"},
@@ -413,6 +527,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 			map[constantValue][]string{
 				`1`: {"DeclTypeNotIgnoredValue"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"DeclTypePartialIgnore", enumMembers{
 			[]string{"DeclTypePartialIgnoreNotIgnored"},
@@ -425,6 +545,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 			map[constantValue][]string{
 				`2`: {"DeclTypePartialIgnoreNotIgnored"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 	}
 
@@ -446,6 +572,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 			map[constantValue][]string{
 				`200`: {"IX", "IY"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"T", enumMembers{
 			[]string{"C", "D", "E", "F"},
@@ -467,6 +599,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				`42`: {"E"},
 				`43`: {"F"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 		{"T", enumMembers{
 			[]string{"A", "B"},
@@ -482,6 +620,12 @@ func checkEnums(t *testing.T, got []checkEnum, pkgOnly bool) {
 				`0`: {"A"},
 				`1`: {"B"},
 			},
+			false,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		}},
 	}
 
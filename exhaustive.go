@@ -3,6 +3,7 @@ package exhaustive
 import (
 	"fmt"
 	"go/ast"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -14,15 +15,29 @@ func init() {
 }
 
 func registerFlags() {
-	Analyzer.Flags.Var(&fCheck, CheckFlag, "comma-separated list of program `elements` to check for exhaustiveness; supported element values: switch, map")
+	Analyzer.Flags.Var(&fCheck, CheckFlag, "comma-separated list of program `elements` to check for exhaustiveness; supported element values: switch, map, typeswitch, array, if")
 	Analyzer.Flags.BoolVar(&fExplicitExhaustiveSwitch, ExplicitExhaustiveSwitchFlag, false, `check switch statement only if associated with "//exhaustive:enforce" comment`)
 	Analyzer.Flags.BoolVar(&fExplicitExhaustiveMap, ExplicitExhaustiveMapFlag, false, `check map literal only if associated with "//exhaustive:enforce" comment`)
+	Analyzer.Flags.BoolVar(&fExplicitExhaustiveArray, ExplicitExhaustiveArrayFlag, false, `check array and slice literal only if associated with "//exhaustive:enforce" comment`)
 	Analyzer.Flags.BoolVar(&fCheckGenerated, CheckGeneratedFlag, false, "check generated files")
 	Analyzer.Flags.BoolVar(&fDefaultSignifiesExhaustive, DefaultSignifiesExhaustiveFlag, false, "switch statement is unconditionally exhaustive if it has a default case")
 	Analyzer.Flags.BoolVar(&fDefaultCaseRequired, DefaultCaseRequiredFlag, false, "switch statement requires default case even if exhaustive")
 	Analyzer.Flags.Var(&fIgnoreEnumMembers, IgnoreEnumMembersFlag, "ignore constants matching `regexp`")
 	Analyzer.Flags.Var(&fIgnoreEnumTypes, IgnoreEnumTypesFlag, "ignore types matching `regexp`")
 	Analyzer.Flags.BoolVar(&fPackageScopeOnly, PackageScopeOnlyFlag, false, "only discover enums declared in file-level blocks")
+	Analyzer.Flags.Var(&fFixCaseBody, FixCaseBodyFlag, "body of case clauses added by suggested fixes, as a Go statement `template`")
+	Analyzer.Flags.Var(&fFixDefaultBody, FixDefaultBodyFlag, "body of default case clauses added by suggested fixes, as a Go statement `template`")
+	Analyzer.Flags.Var(&fFixMapValue, FixMapValueFlag, "value of map elements added by suggested fixes; supported values: "+strings.Join(mapValueChoices, ", "))
+	Analyzer.Flags.BoolVar(&fReportUnnecessaryIgnore, ReportUnnecessaryIgnoreFlag, false, `report "//exhaustive:ignore" comments on switch statements and map literals that are exhaustive without them`)
+	Analyzer.Flags.BoolVar(&fCheckProductKeys, CheckProductKeysFlag, false, "check map literals with struct keys of enum fields, and nested maps with enum keys, for each combination of enum members")
+	Analyzer.Flags.BoolVar(&fMatchValues, MatchValuesFlag, false, "count any constant-valued case expression or map key towards exhaustiveness by its value")
+	Analyzer.Flags.BoolVar(&fReportMemberValues, ReportMemberValuesFlag, false, "report constant-valued case expressions and map keys, other than constant identifiers, that have the value of an enum member")
+	Analyzer.Flags.Var(&fSentinelPattern, SentinelPatternFlag, "treat trailing enum members whose names match `regexp`, and whose values are consistent with a count or maximum, as sentinels that need not be listed")
+	Analyzer.Flags.Var(&fEnumDiscovery, EnumDiscoveryFlag, "enum types to discover; supported values: "+strings.Join(enumDiscoveryChoices, ", "))
+	Analyzer.Flags.BoolVar(&fProtobuf, ProtobufFlag, false, "recognize enums and oneofs generated by protoc-gen-go: the zero _UNSPECIFIED member and the RESERVED placeholder members of a protobuf enum need not be listed, and type switches over oneof interfaces are checked")
+	Analyzer.Flags.BoolVar(&fRequireDeprecated, RequireDeprecatedFlag, false, "require enum members documented as deprecated to be listed in packages other than the enum type's package")
+	Analyzer.Flags.BoolVar(&fReportDeprecated, ReportDeprecatedFlag, false, "report switch statement cases that refer to deprecated enum members declared in other packages")
+	Analyzer.Flags.BoolVar(&fBuildConstraints, BuildConstraintsFlag, false, "discover enum members in files excluded from the build, record the build constraints of enum members, and require a switch statement to list only the members whose build constraints are implied by those of its file")
 
 	var unused string
 	Analyzer.Flags.StringVar(&unused, IgnorePatternFlag, "", "no effect (deprecated); use -"+IgnoreEnumMembersFlag)
@@ -35,29 +50,74 @@ const (
 	CheckFlag                      = "check"
 	ExplicitExhaustiveSwitchFlag   = "explicit-exhaustive-switch"
 	ExplicitExhaustiveMapFlag      = "explicit-exhaustive-map"
+	ExplicitExhaustiveArrayFlag    = "explicit-exhaustive-array"
 	CheckGeneratedFlag             = "check-generated"
 	DefaultSignifiesExhaustiveFlag = "default-signifies-exhaustive"
 	DefaultCaseRequiredFlag        = "default-case-required"
 	IgnoreEnumMembersFlag          = "ignore-enum-members"
 	IgnoreEnumTypesFlag            = "ignore-enum-types"
 	PackageScopeOnlyFlag           = "package-scope-only"
+	FixCaseBodyFlag                = "fix-case-body"
+	FixDefaultBodyFlag             = "fix-default-body"
+	FixMapValueFlag                = "fix-map-value"
+	ReportUnnecessaryIgnoreFlag    = "report-unnecessary-ignore"
+	CheckProductKeysFlag           = "check-product-keys"
+	MatchValuesFlag                = "match-values"
+	ReportMemberValuesFlag         = "report-member-values"
+	SentinelPatternFlag            = "sentinel-pattern"
+	EnumDiscoveryFlag              = "enum-discovery"
+	ProtobufFlag                   = "protobuf"
+	RequireDeprecatedFlag          = "require-deprecated"
+	ReportDeprecatedFlag           = "report-deprecated"
+	BuildConstraintsFlag           = "build-constraints"
 
 	// Deprecated flag names.
 	IgnorePatternFlag    = "ignore-pattern"    // Deprecated: use IgnoreEnumMembersFlag.
 	CheckingStrategyFlag = "checking-strategy" // Deprecated: no longer applicable.
 )
 
+// Diagnostic categories, which identify the kind of each diagnostic
+// reported by the analyzer. These are exported for use by analyzer driver
+// programs, for example as rule IDs.
+const (
+	CategoryMissingCases      = "missing-cases"      // switch statement, type switch statement, or if-else chain with missing cases
+	CategoryMissingKeys       = "missing-keys"       // map with missing keys
+	CategoryMissingElements   = "missing-elements"   // array or slice literal with missing elements
+	CategoryMissingDefault    = "missing-default"    // switch statement without a required default case
+	CategoryInvalidDirective  = "invalid-directive"  // comment directive that fails to parse
+	CategoryUnnecessaryIgnore = "unnecessary-ignore" // ignore directive that isn't needed
+	CategoryMemberValue       = "member-value"       // expression that has the value of an enum member
+	CategoryStrayMember       = "stray-member"       // constant of an annotated enum type that isn't a member
+	CategoryOutsideGroup      = "outside-group"      // case expression outside the enforced group
+	CategoryDeprecatedMember  = "deprecated-member"  // reference to a deprecated enum member
+	CategoryFixUnavailable    = "fix-unavailable"    // suggested fix that cannot be made as configured
+)
+
 // Flag values.
 var (
 	fCheck                      = stringsFlag{elements: defaultCheckElements, filter: validCheckElement}
 	fExplicitExhaustiveSwitch   bool
 	fExplicitExhaustiveMap      bool
+	fExplicitExhaustiveArray    bool
 	fCheckGenerated             bool
 	fDefaultSignifiesExhaustive bool
 	fDefaultCaseRequired        bool
 	fIgnoreEnumMembers          regexpFlag
 	fIgnoreEnumTypes            regexpFlag
 	fPackageScopeOnly           bool
+	fFixCaseBody                templateFlag
+	fFixDefaultBody             templateFlag
+	fFixMapValue                = choiceFlag{value: mapValueZero, choices: mapValueChoices}
+	fReportUnnecessaryIgnore    bool
+	fCheckProductKeys           bool
+	fMatchValues                bool
+	fReportMemberValues         bool
+	fSentinelPattern            regexpFlag
+	fEnumDiscovery              = choiceFlag{value: enumDiscoveryAll, choices: enumDiscoveryChoices}
+	fProtobuf                   bool
+	fRequireDeprecated          bool
+	fReportDeprecated           bool
+	fBuildConstraints           bool
 )
 
 // resetFlags resets the flag variables to default values.
@@ -66,12 +126,26 @@ func resetFlags() {
 	fCheck = stringsFlag{elements: defaultCheckElements, filter: validCheckElement}
 	fExplicitExhaustiveSwitch = false
 	fExplicitExhaustiveMap = false
+	fExplicitExhaustiveArray = false
 	fCheckGenerated = false
 	fDefaultSignifiesExhaustive = false
 	fDefaultCaseRequired = false
 	fIgnoreEnumMembers = regexpFlag{}
 	fIgnoreEnumTypes = regexpFlag{}
 	fPackageScopeOnly = false
+	fFixCaseBody = templateFlag{}
+	fFixDefaultBody = templateFlag{}
+	fFixMapValue = choiceFlag{value: mapValueZero, choices: mapValueChoices}
+	fReportUnnecessaryIgnore = false
+	fCheckProductKeys = false
+	fMatchValues = false
+	fReportMemberValues = false
+	fSentinelPattern = regexpFlag{}
+	fEnumDiscovery = choiceFlag{value: enumDiscoveryAll, choices: enumDiscoveryChoices}
+	fProtobuf = false
+	fRequireDeprecated = false
+	fReportDeprecated = false
+	fBuildConstraints = false
 }
 
 // checkElement is a program element supported by the -check flag.
@@ -80,6 +154,10 @@ type checkElement string
 const (
 	elementSwitch checkElement = "switch"
 	elementMap    checkElement = "map"
+
+	elementTypeSwitch checkElement = "typeswitch"
+	elementArray      checkElement = "array"
+	elementIf         checkElement = "if"
 )
 
 func validCheckElement(s string) error {
@@ -88,6 +166,12 @@ func validCheckElement(s string) error {
 		return nil
 	case elementMap:
 		return nil
+	case elementTypeSwitch:
+		return nil
+	case elementArray:
+		return nil
+	case elementIf:
+		return nil
 	default:
 		return fmt.Errorf("invalid program element %q", s)
 	}
@@ -102,15 +186,44 @@ var Analyzer = &analysis.Analyzer{
 	Doc:       "check exhaustiveness of enum switch statements",
 	Run:       run,
 	Requires:  []*analysis.Analyzer{inspect.Analyzer},
-	FactTypes: []analysis.Fact{&enumMembersFact{}},
+	FactTypes: []analysis.Fact{&enumMembersFact{}, &sealedMembersFact{}, &varEnumMembersFact{}, &extensionMembersFact{}},
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
+	runChecks(pass, nil)
+	return nil, nil
+}
+
+// runChecks discovers the enums in the package, exports their facts, and
+// checks the program elements selected by the -check flag. If recorder is
+// non-nil, it records the outcomes of the checks of switch statements and
+// map literals.
+func runChecks(pass *analysis.Pass, recorder *checkRecorder) {
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
-	for typ, members := range findEnums(pass, fPackageScopeOnly, pass.Pkg, inspect, pass.TypesInfo) {
+	enums := discoverEnums(pass, inspect)
+	for typ, members := range enums {
 		exportFact(pass, typ, members)
 	}
+	ext := findExtensionMembers(pass, inspect)
+	if len(ext) != 0 {
+		pass.ExportPackageFact(makeExtensionMembersFact(ext))
+	}
+	if fEnumDiscovery.value == enumDiscoveryAnnotated {
+		reportStrayMembers(pass, inspect, findDirectiveTypes(inspect, pass.TypesInfo, enumDirective), ext)
+	}
+	typeEnums, blockEnums := findVarEnums(pass.Pkg, inspect, pass.TypesInfo, enums)
+	for typ, members := range typeEnums {
+		exportVarFact(pass, typ.factObject(), members)
+	}
+	for _, b := range blockEnums {
+		for _, v := range b.vars {
+			exportVarFact(pass, v, b.members)
+		}
+	}
+	for intf, members := range findSealedInterfaces(pass.Pkg, inspect, pass.TypesInfo) {
+		exportSealedFact(pass, intf, members)
+	}
 
 	generated := boolCache{compute: isGeneratedFile}
 	comments := commentCache{compute: fileCommentMap}
@@ -119,7 +232,16 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	// program elements: the visitor function for a program element may
 	// exit traversal early, but this shouldn't affect traversal for
 	// other program elements.
-	for _, e := range fCheck.elements {
+	elements := fCheck.elements
+	var oneofsOnly bool
+	if fProtobuf && !hasElement(elements, string(elementTypeSwitch)) {
+		// In the protobuf mode, type switches over oneof interfaces are
+		// checked even if type switches aren't.
+		elements = append(elements[:len(elements):len(elements)], string(elementTypeSwitch))
+		oneofsOnly = true
+	}
+
+	for _, e := range elements {
 		switch checkElement(e) {
 		case elementSwitch:
 			conf := switchConfig{
@@ -129,24 +251,75 @@ func run(pass *analysis.Pass) (interface{}, error) {
 				checkGenerated:             fCheckGenerated,
 				ignoreConstant:             fIgnoreEnumMembers.re,
 				ignoreType:                 fIgnoreEnumTypes.re,
+				caseBody:                   fFixCaseBody.tmpl,
+				defaultBody:                fFixDefaultBody.tmpl,
+				reportUnnecessaryIgnore:    fReportUnnecessaryIgnore,
+				matchValues:                fMatchValues,
+				reportMemberValues:         fReportMemberValues,
+				requireDeprecated:          fRequireDeprecated,
+				reportDeprecated:           fReportDeprecated,
+				buildConstraints:           fBuildConstraints,
 			}
-			checker := switchChecker(pass, conf, generated, comments)
+			checker := recorder.record(CheckNodeSwitch, switchEnumTypes(pass), switchChecker(pass, conf, generated, comments))
 			inspect.WithStack([]ast.Node{&ast.SwitchStmt{}}, toVisitor(checker))
 
 		case elementMap:
 			conf := mapConfig{
-				explicit:       fExplicitExhaustiveMap,
-				checkGenerated: fCheckGenerated,
-				ignoreConstant: fIgnoreEnumMembers.re,
-				ignoreType:     fIgnoreEnumTypes.re,
+				explicit:                fExplicitExhaustiveMap,
+				checkGenerated:          fCheckGenerated,
+				ignoreConstant:          fIgnoreEnumMembers.re,
+				ignoreType:              fIgnoreEnumTypes.re,
+				fixValue:                fFixMapValue.value,
+				reportUnnecessaryIgnore: fReportUnnecessaryIgnore,
+				productKeys:             fCheckProductKeys,
+				matchValues:             fMatchValues,
+				reportMemberValues:      fReportMemberValues,
+				requireDeprecated:       fRequireDeprecated,
 			}
-			checker := mapChecker(pass, conf, generated, comments)
+			checker := recorder.record(CheckNodeMap, mapEnumTypes(pass), mapChecker(pass, conf, generated, comments))
 			inspect.WithStack([]ast.Node{&ast.CompositeLit{}}, toVisitor(checker))
+			checkMapAssignments(pass, conf, generated, comments, inspect)
+
+		case elementTypeSwitch:
+			conf := typeSwitchConfig{
+				explicit:                   fExplicitExhaustiveSwitch,
+				defaultSignifiesExhaustive: fDefaultSignifiesExhaustive,
+				checkGenerated:             fCheckGenerated,
+				ignoreType:                 fIgnoreEnumTypes.re,
+				caseBody:                   fFixCaseBody.tmpl,
+				oneofsOnly:                 oneofsOnly,
+			}
+			checker := typeSwitchChecker(pass, conf, generated, comments)
+			inspect.WithStack([]ast.Node{&ast.TypeSwitchStmt{}}, toVisitor(checker))
+
+		case elementArray:
+			conf := arrayConfig{
+				explicit:                fExplicitExhaustiveArray,
+				checkGenerated:          fCheckGenerated,
+				ignoreConstant:          fIgnoreEnumMembers.re,
+				ignoreType:              fIgnoreEnumTypes.re,
+				reportUnnecessaryIgnore: fReportUnnecessaryIgnore,
+				requireDeprecated:       fRequireDeprecated,
+			}
+			checker := arrayChecker(pass, conf, generated, comments)
+			inspect.WithStack([]ast.Node{&ast.CompositeLit{}}, toVisitor(checker))
+
+		case elementIf:
+			conf := ifChainConfig{
+				explicit:                   fExplicitExhaustiveSwitch,
+				defaultSignifiesExhaustive: fDefaultSignifiesExhaustive,
+				checkGenerated:             fCheckGenerated,
+				ignoreConstant:             fIgnoreEnumMembers.re,
+				ignoreType:                 fIgnoreEnumTypes.re,
+				reportUnnecessaryIgnore:    fReportUnnecessaryIgnore,
+				requireDeprecated:          fRequireDeprecated,
+			}
+			checker := ifChainChecker(pass, conf, generated, comments)
+			inspect.WithStack([]ast.Node{&ast.IfStmt{}}, toVisitor(checker))
 
 		default:
 			panic(fmt.Sprintf("unknown checkElement %v", e))
 		}
 	}
 
-	return nil, nil
 }
@@ -7,8 +7,12 @@ import (
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
+// testSentinelPattern is a value of the -sentinel-pattern flag that
+// matches names such as NumKinds, maxKind, _last, KindCount, and kindEnd.
+const testSentinelPattern = `^_?([nN]um|[mM]ax|[cC]ount|[lL]ast|[eE]nd)([A-Z_]|$)|(Count|Max|Last|End)$`
+
 func TestExhaustive(t *testing.T) {
-	runTest := func(t *testing.T, pattern string, setup ...func()) {
+	run := func(t *testing.T, pattern string, withFixes bool, setup ...func()) {
 		t.Helper()
 		t.Run(pattern, func(t *testing.T) {
 			resetFlags()
@@ -23,9 +27,23 @@ func TestExhaustive(t *testing.T) {
 			for _, f := range setup {
 				f()
 			}
-			analysistest.Run(t, analysistest.TestData(), Analyzer, pattern)
+			if withFixes {
+				analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, pattern)
+			} else {
+				analysistest.Run(t, analysistest.TestData(), Analyzer, pattern)
+			}
 		})
 	}
+	runTest := func(t *testing.T, pattern string, setup ...func()) {
+		t.Helper()
+		run(t, pattern, false, setup...)
+	}
+	// runFixTest is like runTest, but additionally checks suggested fixes
+	// against .golden files.
+	runFixTest := func(t *testing.T, pattern string, setup ...func()) {
+		t.Helper()
+		run(t, pattern, true, setup...)
+	}
 
 	if !testing.Short() {
 		// Analysis of code that uses complex packages, such as package os and
@@ -68,6 +86,12 @@ func TestExhaustive(t *testing.T) {
 	// checked during implicitly exhaustive mode.
 	runTest(t, "ignore-comment/...")
 
+	// Tests for the -report-unnecessary-ignore flag.
+	runFixTest(t, "unnecessary-ignore/...", func() {
+		fReportUnnecessaryIgnore = true
+		fCheck.elements = append(fCheck.elements, string(elementArray))
+	})
+
 	// Program elements without enforce comment should not be
 	// checked in explicitly exhaustive mode.
 	runTest(t, "enforce-comment/...", func() {
@@ -75,10 +99,125 @@ func TestExhaustive(t *testing.T) {
 		fExplicitExhaustiveMap = true
 	})
 
+	// Invalid directives and near misses, with suggested corrections.
+	runFixTest(t, "directive-typo/...")
+
+	// Directives that apply only to declarations are invalid elsewhere.
+	runTest(t, "misplaced-directive/...", func() {
+		fCheck.elements = append(fCheck.elements, string(elementArray), string(elementIf))
+		fCheckProductKeys = true
+	})
+
 	// To satisfy exhaustiveness, it is sufficient for each unique constant
 	// value of the members to be listed, not each member by name.
 	runTest(t, "duplicate-enum-value/...")
 
+	// Tests for suggested fixes that add missing cases, and for the
+	// -fix-case-body flag.
+	runFixTest(t, "fix-case-body/empty-body/...")
+	runFixTest(t, "fix-case-body/template-body/...", func() {
+		assertNoError(t, fFixCaseBody.Set("println(\"{{.Member}}\")\npanic({{.Tag}})"))
+	})
+
+	// Tests for suggested fixes that add a default case, and for the
+	// -fix-default-body flag.
+	runFixTest(t, "fix-default-body/empty-body/...", func() { fDefaultCaseRequired = true })
+	runFixTest(t, "fix-default-body/template-body/...", func() {
+		fDefaultCaseRequired = true
+		assertNoError(t, fFixDefaultBody.Set(`panic(fmt.Sprintf("unexpected %T: %v", {{.Tag}}, {{.Tag}}))`))
+	})
+	runFixTest(t, "fix-default-body/import-body/...", func() {
+		fDefaultCaseRequired = true
+		assertNoError(t, fFixDefaultBody.Set("err := errors.New(\"unexpected\")\npanic(err.Error() + string(rune(rand.Int())))"))
+	})
+
+	// Tests for suggested fixes that add missing map keys, and for the
+	// -fix-map-value flag.
+	runFixTest(t, "fix-map-value/zero-value/...")
+	runFixTest(t, "fix-map-value/name-value/...", func() { assertNoError(t, fFixMapValue.Set(mapValueName)) })
+	runFixTest(t, "fix-map-value/snake-value/...", func() { assertNoError(t, fFixMapValue.Set(mapValueSnake)) })
+
+	// Tests for type switches over sealed interfaces.
+	runFixTest(t, "typeswitch/basic/...", func() {
+		fCheck.elements = append(fCheck.elements, string(elementTypeSwitch))
+	})
+	runFixTest(t, "typeswitch/template-body/...", func() {
+		fCheck.elements = append(fCheck.elements, string(elementTypeSwitch))
+		assertNoError(t, fFixCaseBody.Set(`panic(fmt.Sprintf("unhandled %T (%v)", {{.Tag}}, "{{.Type}}"))`))
+	})
+
+	// Enums whose members are package-level variables.
+	runTest(t, "var-enum/...", func() {
+		fCheck.elements = append(fCheck.elements, string(elementIf))
+	})
+
+	// Tests for bit-flag enums.
+	runFixTest(t, "flags/...", func() {
+		fCheck.elements = append(fCheck.elements, string(elementIf))
+	})
+
+	// Tests for value-based matching.
+	runFixTest(t, "match-values/...", func() { fMatchValues = true })
+	runFixTest(t, "report-member-values/...", func() {
+		fMatchValues = true
+		fReportMemberValues = true
+	})
+
+	// Tests for sentinel members.
+	runTest(t, "sentinel/...", func() { assertNoError(t, fSentinelPattern.Set(testSentinelPattern)) })
+	runTest(t, "sentinel-default/...")
+	runTest(t, "sentinel-pattern/...", func() { assertNoError(t, fSentinelPattern.Set("Sentinel$")) })
+
+	// Tests for annotated enum discovery.
+	runTest(t, "enum-discovery/...", func() { assertNoError(t, fEnumDiscovery.Set(enumDiscoveryAnnotated)) })
+
+	// Tests for enum members declared in other packages.
+	runTest(t, "member-of/...")
+	runTest(t, "member-of-annotated/...", func() { assertNoError(t, fEnumDiscovery.Set(enumDiscoveryAnnotated)) })
+
+	// Tests for named groups of enum members.
+	runTest(t, "named-groups/...")
+
+	// Tests for deprecated enum members.
+	runTest(t, "deprecated/...")
+	runTest(t, "deprecated-require/...", func() { fRequireDeprecated = true })
+	runFixTest(t, "deprecated-report/...", func() {
+		fReportDeprecated = true
+		fDefaultSignifiesExhaustive = true
+	})
+
+	// Tests for build constraints of enum members.
+	runTest(t, "build-constraints/...", func() { fBuildConstraints = true })
+
+	// Tests for the protobuf mode.
+	runTest(t, "protobuf/...", func() { fProtobuf = true })
+
+	// Tests for map literals with product keys.
+	runTest(t, "product-keys/...", func() {
+		fCheckProductKeys = true
+		fReportUnnecessaryIgnore = true
+	})
+
+	// Tests for maps populated by index assignments.
+	runTest(t, "map-assign/...")
+
+	// Tests for enum-indexed array and slice literals.
+	runTest(t, "array-literal/...", func() {
+		fCheck.elements = append(fCheck.elements, string(elementArray))
+	})
+
+	// Tests for tagless switch statements.
+	runFixTest(t, "tagless-switch/...")
+
+	// Tests for if-else chains.
+	runTest(t, "if-chain", func() {
+		fCheck.elements = append(fCheck.elements, string(elementIf))
+	})
+	runTest(t, "if-chain/default-signifies-exhaustive/...", func() {
+		fCheck.elements = append(fCheck.elements, string(elementIf))
+		fDefaultSignifiesExhaustive = true
+	})
+
 	runTest(t, "typealias/...")
 	runTest(t, "typeparam/...")
 
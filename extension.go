@@ -0,0 +1,152 @@
+package exhaustive
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// An extension member is a constant, declared in a package other than
+// that of its enum type, that is registered as a member of the enum type
+// using an "//exhaustive:member-of" comment, as in:
+//
+//	package plugin
+//
+//	//exhaustive:member-of core.Kind
+//	const Custom core.Kind = 100
+//
+// The extension members declared in a package are recorded in the
+// package's extensionMembersFact. A package sees the extension members
+// declared in the packages that it depends on, and in itself; see
+// extensionsOf.
+
+// typeKey returns the key for the package-level type in
+// extensionMembersFact.
+func typeKey(tn *types.TypeName) string {
+	return tn.Pkg().Path() + "." + tn.Name()
+}
+
+// findExtensionMembers finds the extension members declared in the
+// package, keyed by their enum type, and reports invalid member-of
+// directives.
+func findExtensionMembers(pass *analysis.Pass, inspect *inspector.Inspector) map[*types.TypeName]enumMembers {
+	result := make(map[*types.TypeName]enumMembers)
+
+	inspect.WithStack([]ast.Node{&ast.GenDecl{}}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		gen := n.(*ast.GenDecl)
+		if gen.Tok != token.CONST {
+			return true
+		}
+		file := stack[0].(*ast.File)
+		// An extension member has to be named by a package-qualified
+		// identifier wherever its enum type is used.
+		local := len(stack) > 2
+
+		for _, s := range gen.Specs {
+			s := s.(*ast.ValueSpec)
+			groups := []*ast.CommentGroup{gen.Doc, s.Doc}
+			c := findDirectiveComment(groups, memberOfComment)
+			if c == nil {
+				continue
+			}
+			if local {
+				pass.Report(makeInvalidDirectiveDiagnostic(c, fmt.Errorf("%q directive applies only to package-level constants", memberOfComment)))
+				continue
+			}
+			_, args, _ := splitDirective(c.Text)
+			var arg string // the type name; any text after it is ignored
+			if fields := strings.Fields(args); len(fields) != 0 {
+				arg = fields[0]
+			}
+			tn, err := resolveMemberOfType(pass, file, arg)
+			if err != nil {
+				pass.Report(makeInvalidDirectiveDiagnostic(c, err))
+				continue
+			}
+			for _, name := range s.Names {
+				obj, ok := pass.TypesInfo.Defs[name].(*types.Const)
+				if !ok || isBlankIdentifier(obj.Name()) {
+					continue
+				}
+				if named, ok := obj.Type().(*types.Named); !ok || named.Obj() != tn {
+					pass.Report(makeInvalidDirectiveDiagnostic(c, fmt.Errorf("constant %s is not of type %s", obj.Name(), arg)))
+					continue
+				}
+				em := result[tn]
+				em.add(obj.Name(), determineConstVal(name, pass.TypesInfo), name.Pos())
+				result[tn] = em
+			}
+		}
+		return true
+	})
+
+	return result
+}
+
+// makeExtensionMembersFact returns the fact that records the extension
+// members, as returned by findExtensionMembers.
+func makeExtensionMembersFact(ext map[*types.TypeName]enumMembers) *extensionMembersFact {
+	members := make(map[string]enumMembers, len(ext))
+	for tn, em := range ext {
+		members[typeKey(tn)] = em
+	}
+	return &extensionMembersFact{members}
+}
+
+// resolveMemberOfType returns the type named by the argument of a
+// member-of directive in the file. The argument is the name of a
+// package-level type in another package, qualified by the name that the
+// file imports the package with.
+func resolveMemberOfType(pass *analysis.Pass, file *ast.File, arg string) (*types.TypeName, error) {
+	i := strings.Index(arg, ".")
+	if i == -1 {
+		return nil, fmt.Errorf("%q directive requires a package-qualified type name, got %q", memberOfComment, arg)
+	}
+	pkgName, ok := pass.TypesInfo.Scopes[file].Lookup(arg[:i]).(*types.PkgName)
+	if !ok {
+		return nil, fmt.Errorf("unknown package %q in %q directive", arg[:i], memberOfComment)
+	}
+	tn, ok := pkgName.Imported().Scope().Lookup(arg[i+1:]).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q in %q directive", arg, memberOfComment)
+	}
+	return tn, nil
+}
+
+// extensionsOf returns, for the enum type, the extension members declared
+// in each package whose extensionMembersFact is visible to the pass. The
+// enum type of each result is a type name, declared in the package of
+// the extension members but not in any scope, whose type is the enum
+// type; see diagnosticEnumType. The extension members of a bit-flag enum
+// are bit flags too.
+func extensionsOf(pass *analysis.Pass, et enumType, base enumMembers) []enumTypeAndMembers {
+	var result []enumTypeAndMembers
+	key := typeKey(et.TypeName)
+	for _, f := range pass.AllPackageFacts() {
+		fact, ok := f.Fact.(*extensionMembersFact)
+		if !ok {
+			continue
+		}
+		em, ok := fact.Members[key]
+		if !ok {
+			continue
+		}
+		em.Flags = base.Flags
+		tn := types.NewTypeName(token.NoPos, f.Package, et.Name(), et.Type())
+		result = append(result, enumTypeAndMembers{enumType{tn}, em})
+	}
+	// The order of AllPackageFacts is unspecified.
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].typ.Pkg().Path() < result[j].typ.Pkg().Path()
+	})
+	return result
+}
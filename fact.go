@@ -1,11 +1,20 @@
 package exhaustive
 
-import "golang.org/x/tools/go/analysis"
+import (
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
 
 // NOTE: Fact types must remain gob-coding compatible.
 // See TestFactsGob.
 
 var _ analysis.Fact = (*enumMembersFact)(nil)
+var _ analysis.Fact = (*sealedMembersFact)(nil)
+var _ analysis.Fact = (*varEnumMembersFact)(nil)
+var _ analysis.Fact = (*extensionMembersFact)(nil)
 
 type enumMembersFact struct{ Members enumMembers }
 
@@ -26,3 +35,72 @@ func importFact(pass *analysis.Pass, possibleEnumType enumType) (enumMembers, bo
 	}
 	return f.Members, true
 }
+
+type sealedMembersFact struct{ Members sealedMembers }
+
+func (f *sealedMembersFact) AFact()         {}
+func (f *sealedMembersFact) String() string { return f.Members.factString() }
+
+// exportSealedFact exports the implementing types for the given sealed
+// interface type.
+func exportSealedFact(pass *analysis.Pass, intf *types.TypeName, members sealedMembers) {
+	pass.ExportObjectFact(intf, &sealedMembersFact{members})
+}
+
+// importSealedFact imports the implementing types for the given possible
+// sealed interface type. An (_, false) return indicates that the type is
+// not a known sealed interface.
+func importSealedFact(pass *analysis.Pass, possibleIntf *types.TypeName) (sealedMembers, bool) {
+	var f sealedMembersFact
+	if !pass.ImportObjectFact(possibleIntf, &f) {
+		return sealedMembers{}, false
+	}
+	return f.Members, true
+}
+
+type varEnumMembersFact struct{ Members enumMembers }
+
+func (f *varEnumMembersFact) AFact()         {}
+func (f *varEnumMembersFact) String() string { return f.Members.factString() }
+
+// exportVarFact exports the members of a variable enum for the given
+// object, which is either the enum type or a member variable.
+func exportVarFact(pass *analysis.Pass, obj types.Object, members enumMembers) {
+	pass.ExportObjectFact(obj, &varEnumMembersFact{members})
+}
+
+// importVarFact imports the members of the variable enum for the given
+// object. An (_, false) return indicates that the object is neither a
+// known variable enum type nor a member of a variable enum declared on a
+// var declaration.
+func importVarFact(pass *analysis.Pass, obj types.Object) (enumMembers, bool) {
+	var f varEnumMembersFact
+	if !pass.ImportObjectFact(obj, &f) {
+		return enumMembers{}, false
+	}
+	return f.Members, true
+}
+
+// extensionMembersFact is a package fact that records the extension
+// members declared in a package, keyed by the typeKey of their enum type.
+type extensionMembersFact struct {
+	Members map[string]enumMembers
+}
+
+func (f *extensionMembersFact) AFact() {}
+func (f *extensionMembersFact) String() string {
+	keys := make([]string, 0, len(f.Members))
+	for k := range f.Members {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var buf strings.Builder
+	for i, k := range keys {
+		if i != 0 {
+			buf.WriteString(";")
+		}
+		em := f.Members[k]
+		buf.WriteString(k + ":" + em.factString())
+	}
+	return buf.String()
+}
@@ -125,6 +125,12 @@ func checkOneFactType(t *testing.T, fact analysis.Fact) {
 		// NOTE: if there are more fact types, add them here.
 		case *enumMembersFact:
 			checkTypeEnumMembersFact(t, reflect.TypeOf(v).Elem())
+		case *varEnumMembersFact:
+			checkTypeEnumMembersFact(t, reflect.TypeOf(v).Elem())
+		case *sealedMembersFact:
+			checkTypeSealedMembersFact(t, reflect.TypeOf(v).Elem())
+		case *extensionMembersFact:
+			checkTypeExtensionMembersFact(t, reflect.TypeOf(v).Elem())
 		default:
 			t.Errorf("unhandled type %T", v)
 		}
@@ -155,6 +161,12 @@ func checkTypeEnumMembers(t *testing.T, enumMembersType reflect.Type) {
 		{"NameToPos", "map[string]token.Pos"},
 		{"NameToValue", "map[string]exhaustive.constantValue"},
 		{"ValueToNames", "map[exhaustive.constantValue][]string"},
+		{"Flags", "bool"},
+		{"Sentinels", "map[string]bool"},
+		{"Optional", "map[string]bool"},
+		{"Groups", "map[string][]string"},
+		{"Deprecated", "map[string]string"},
+		{"Constraints", "map[string]string"},
 	})
 
 	// Check that types such as token.Pos and constantValue have basic
@@ -183,6 +195,40 @@ func checkTypeEnumMembers(t *testing.T, enumMembersType reflect.Type) {
 	}
 }
 
+func checkTypeExtensionMembersFact(t *testing.T, factType reflect.Type) {
+	t.Helper()
+
+	assertTypeFields(t, factType, []wantField{
+		{"Members", "map[string]exhaustive.enumMembers"},
+	})
+
+	field, ok := factType.FieldByName("Members")
+	if !ok {
+		t.Errorf("failed to find field")
+		return
+	}
+	checkTypeEnumMembers(t, field.Type.Elem())
+}
+
+func checkTypeSealedMembersFact(t *testing.T, factType reflect.Type) {
+	t.Helper()
+
+	assertTypeFields(t, factType, []wantField{
+		{"Members", "exhaustive.sealedMembers"},
+	})
+
+	field, ok := factType.FieldByName("Members")
+	if !ok {
+		t.Errorf("failed to find field")
+		return
+	}
+	assertTypeFields(t, field.Type, []wantField{
+		{"Names", "[]string"},
+		{"NameToPtr", "map[string]bool"},
+		{"NameToPos", "map[string]token.Pos"},
+	})
+}
+
 func assertTypeFields(t *testing.T, typ reflect.Type, wantFields []wantField) {
 	t.Helper()
 
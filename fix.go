@@ -0,0 +1,561 @@
+package exhaustive
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// fixData is the data available to the templates that specify the
+// statements inserted by suggested fixes. See the -fix-case-body flag.
+type fixData struct {
+	Type   string // enum type(s) or sealed interface type, e.g. "token.Token"
+	Member string // enum member or type in the case clause, e.g. "token.Add"
+
+	tag     string // expression that refers to the switch tag
+	usedTag bool   // whether the template referred to the switch tag
+}
+
+// Tag returns the expression that refers to the switch tag.
+func (d *fixData) Tag() string {
+	d.usedTag = true
+	return d.tag
+}
+
+// executeFixTemplate executes the template with the supplied data and
+// returns the resulting statements, one per line. A nil template
+// expands to no statements.
+func executeFixTemplate(tmpl *template.Template, data *fixData) ([]string, error) {
+	if tmpl == nil {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	s := strings.TrimSpace(buf.String())
+	if s == "" {
+		return nil, nil
+	}
+	return strings.Split(s, "\n"), nil
+}
+
+// writeStmts writes the statements to buf, each on its own line and
+// prefixed by indent.
+func writeStmts(buf *strings.Builder, stmts []string, indent string) {
+	for _, s := range stmts {
+		buf.WriteString(indent)
+		buf.WriteString(strings.TrimSpace(s))
+		buf.WriteByte('\n')
+	}
+}
+
+// importEdits returns edits that add imports to file for the packages
+// that the statements, to be inserted at pos, refer to but that are
+// neither imported by file nor otherwise in scope at pos. The declared
+// names are names that the fix itself declares; names that the
+// statements declare are found by declaredNames. The import path of a
+// package is determined by resolveImport. If a package cannot be
+// resolved, a fix cannot be made, and the error is an
+// unresolvedPackageError.
+//
+// Each import is added by its own edit, at the position at which gofmt
+// would sort it, so that the identical edits of several fixes in a file
+// are applied once.
+func importEdits(pass *analysis.Pass, file *ast.File, pos token.Pos, stmts []string, declared ...string) ([]analysis.TextEdit, error) {
+	if len(stmts) == 0 {
+		return nil, nil
+	}
+	lit, err := parser.ParseExpr("func() {\n" + strings.Join(stmts, "\n") + "\n}")
+	if err != nil {
+		return nil, nil
+	}
+
+	scope := pass.Pkg.Scope().Innermost(pos)
+	if scope == nil {
+		scope = pass.Pkg.Scope()
+	}
+
+	seen := declaredNames(lit.(*ast.FuncLit))
+	for _, name := range declared {
+		seen[name] = true
+	}
+	var paths []string
+	var unresolved error
+	ast.Inspect(lit, func(n ast.Node) bool {
+		sel, isSel := n.(*ast.SelectorExpr)
+		if !isSel {
+			return true
+		}
+		x, isIdent := sel.X.(*ast.Ident)
+		if !isIdent || seen[x.Name] {
+			return true
+		}
+		seen[x.Name] = true
+		if _, obj := scope.LookupParent(x.Name, pos); obj != nil {
+			return true
+		}
+		path, ok := resolveImport(pass, file, x.Name)
+		if !ok {
+			unresolved = &unresolvedPackageError{x.Name}
+			return false
+		}
+		paths = append(paths, path)
+		return true
+	})
+	if unresolved != nil {
+		return nil, unresolved
+	}
+	sort.Strings(paths)
+	var edits []analysis.TextEdit
+	for _, p := range paths {
+		edits = append(edits, importEdit(pass.Fset, file, p))
+	}
+	return edits, nil
+}
+
+// declaredNames returns the names that the function literal declares,
+// including its parameters and the variables, constants, types, and
+// labels declared in its body.
+func declaredNames(lit *ast.FuncLit) map[string]bool {
+	names := make(map[string]bool)
+	addIdent := func(e ast.Expr) {
+		if id, ok := e.(*ast.Ident); ok {
+			names[id.Name] = true
+		}
+	}
+	addFields := func(fl *ast.FieldList) {
+		if fl == nil {
+			return
+		}
+		for _, f := range fl.List {
+			for _, name := range f.Names {
+				names[name.Name] = true
+			}
+		}
+	}
+	ast.Inspect(lit, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncType:
+			addFields(n.Params)
+			addFields(n.Results)
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE {
+				for _, e := range n.Lhs {
+					addIdent(e)
+				}
+			}
+		case *ast.RangeStmt:
+			if n.Tok == token.DEFINE {
+				addIdent(n.Key)
+				addIdent(n.Value)
+			}
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				names[name.Name] = true
+			}
+		case *ast.TypeSpec:
+			names[n.Name.Name] = true
+		case *ast.LabeledStmt:
+			names[n.Label.Name] = true
+		}
+		return true
+	})
+	return names
+}
+
+// resolveImport returns the import path of the package that code in file
+// refers to by name, where the name is not in scope. The package is one
+// that file imports under another name, or else a standard library package
+// listed in stdImports. Other packages are not resolved, even if the
+// package being analyzed or its dependencies import them, because a name
+// may refer to more than one package. The ok return value is false if the
+// package cannot be resolved.
+func resolveImport(pass *analysis.Pass, file *ast.File, name string) (path string, ok bool) {
+	paths := make(map[string]bool) // import path
+	for _, spec := range file.Imports {
+		var obj types.Object
+		if spec.Name != nil {
+			obj = pass.TypesInfo.Defs[spec.Name]
+		} else {
+			obj = pass.TypesInfo.Implicits[spec]
+		}
+		if pkgName, ok := obj.(*types.PkgName); ok && pkgName.Imported().Name() == name {
+			paths[pkgName.Imported().Path()] = true
+		}
+	}
+	if len(paths) != 0 {
+		return onlyKey(paths)
+	}
+	path, ok = stdImports[name]
+	return path, ok
+}
+
+// stdImports maps the names of commonly used standard library packages to
+// their import paths. Names shared by more than one standard library
+// package, such as rand and template, are omitted.
+var stdImports = map[string]string{
+	"atomic":   "sync/atomic",
+	"bytes":    "bytes",
+	"context":  "context",
+	"debug":    "runtime/debug",
+	"errors":   "errors",
+	"filepath": "path/filepath",
+	"fmt":      "fmt",
+	"io":       "io",
+	"json":     "encoding/json",
+	"log":      "log",
+	"math":     "math",
+	"os":       "os",
+	"reflect":  "reflect",
+	"runtime":  "runtime",
+	"sort":     "sort",
+	"strconv":  "strconv",
+	"strings":  "strings",
+	"sync":     "sync",
+	"time":     "time",
+	"unicode":  "unicode",
+}
+
+// onlyKey returns the key of the set, if the set has exactly one key.
+func onlyKey(set map[string]bool) (string, bool) {
+	if len(set) != 1 {
+		return "", false
+	}
+	for k := range set {
+		return k, true
+	}
+	panic("unreachable")
+}
+
+// errNoFix is returned when a suggested fix cannot be made for a reason
+// that is not worth reporting; for example, if the file doesn't import a
+// member's package.
+var errNoFix = errors.New("no suggested fix")
+
+// An unresolvedPackageError is returned when a suggested fix cannot be
+// made because its statements refer to a package that cannot be resolved;
+// see resolveImport.
+type unresolvedPackageError struct {
+	name string
+}
+
+func (e *unresolvedPackageError) Error() string {
+	return fmt.Sprintf("cannot suggest fix: package %s, referred to by the fix template, is not imported by the file", e.name)
+}
+
+// A tagSideEffectsError is returned when a suggested fix cannot be made
+// because its statements refer to the operand of a tagless switch
+// statement, and evaluating the operand again may have side effects.
+type tagSideEffectsError struct {
+	expr string
+}
+
+func (e *tagSideEffectsError) Error() string {
+	return fmt.Sprintf("cannot suggest fix: the fix template refers to the switch operand %s, which may have side effects", e.expr)
+}
+
+// reportFixError reports the error of making a suggested fix for the node
+// if the error is one the user can act on: an unresolvedPackageError, by
+// importing the package, or a tagSideEffectsError, by binding the operand
+// to a variable.
+func reportFixError(node ast.Node, err error, report func(analysis.Diagnostic)) {
+	var upe *unresolvedPackageError
+	var tse *tagSideEffectsError
+	if !errors.As(err, &upe) && !errors.As(err, &tse) {
+		return
+	}
+	report(analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: CategoryFixUnavailable,
+		Message:  err.Error(),
+	})
+}
+
+// importEdit returns an edit that adds an import of the package at path
+// to file. In an import declaration with parentheses, the import is added
+// to the group of imports that gofmt would sort it into: the group of
+// standard library imports, if path is one, or else the last group.
+func importEdit(fset *token.FileSet, file *ast.File, path string) analysis.TextEdit {
+	var lastImport *ast.GenDecl
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			lastImport = gen
+		}
+	}
+	quoted := strconv.Quote(path)
+
+	switch {
+	case lastImport != nil && lastImport.Lparen.IsValid() && len(lastImport.Specs) != 0:
+		// Split the specs into groups separated by blank lines.
+		var groups [][]*ast.ImportSpec
+		prevLine := -1
+		for _, spec := range lastImport.Specs {
+			spec := spec.(*ast.ImportSpec)
+			start := spec.Pos()
+			if spec.Doc != nil {
+				start = spec.Doc.Pos()
+			}
+			if line := fset.Position(start).Line; prevLine < 0 || line > prevLine+1 {
+				groups = append(groups, nil)
+			}
+			groups[len(groups)-1] = append(groups[len(groups)-1], spec)
+			prevLine = fset.Position(spec.End()).Line
+		}
+		group := groups[len(groups)-1]
+		if isStdImport(path) {
+			for _, g := range groups {
+				if isStdImport(importPath(g[0])) {
+					group = g
+					break
+				}
+			}
+		}
+		for _, spec := range group {
+			if importPath(spec) > path {
+				pos := spec.Pos()
+				if spec.Doc != nil {
+					pos = spec.Doc.Pos()
+				}
+				return analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(quoted + "\n\t")}
+			}
+		}
+		pos := group[len(group)-1].End()
+		return analysis.TextEdit{Pos: pos, End: pos, NewText: []byte("\n\t" + quoted)}
+	case lastImport != nil && lastImport.Lparen.IsValid():
+		return analysis.TextEdit{Pos: lastImport.Rparen, End: lastImport.Rparen, NewText: []byte("\t" + quoted + "\n")}
+	case lastImport != nil:
+		return analysis.TextEdit{Pos: lastImport.End(), End: lastImport.End(), NewText: []byte("\nimport " + quoted)}
+	default:
+		return analysis.TextEdit{Pos: file.Name.End(), End: file.Name.End(), NewText: []byte("\n\nimport " + quoted)}
+	}
+}
+
+// importPath returns the unquoted import path of the spec.
+func importPath(spec *ast.ImportSpec) string {
+	path, _ := strconv.Unquote(spec.Path.Value)
+	return path
+}
+
+// isStdImport reports whether path is the import path of a standard
+// library package, using the heuristic that the first element of such a
+// path has no dot.
+func isStdImport(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// packageQualifier returns the qualifier that code in file, which belongs
+// to package from, must use to refer to package-level identifiers
+// declared in pkg. The qualifier is empty if pkg is the same package or
+// if it is dot-imported. The ok return value is false if the file does not
+// import pkg.
+func packageQualifier(file *ast.File, from, pkg *types.Package) (qual string, ok bool) {
+	if pkg == from {
+		return "", true
+	}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || path != pkg.Path() {
+			continue
+		}
+		if spec.Name == nil {
+			return pkg.Name(), true
+		}
+		switch spec.Name.Name {
+		case "_":
+			continue
+		case ".":
+			return "", true
+		default:
+			return spec.Name.Name, true
+		}
+	}
+	return "", false
+}
+
+// memberExpr returns the expression that code in file, which belongs to
+// package from, must use to refer to the enum member.
+func memberExpr(file *ast.File, from *types.Package, m member) (string, bool) {
+	qual, ok := packageQualifier(file, from, m.typ.Pkg())
+	if !ok {
+		return "", false
+	}
+	if qual == "" {
+		return m.name, true
+	}
+	return qual + "." + m.name, true
+}
+
+// typeExpr returns the expression that code in file, which belongs to
+// package from, must use to refer to the type. The ok return value is
+// false if the file does not import a package that the type refers to.
+func typeExpr(file *ast.File, from *types.Package, t types.Type) (expr string, ok bool) {
+	ok = true
+	expr = types.TypeString(t, func(pkg *types.Package) string {
+		qual, imported := packageQualifier(file, from, pkg)
+		ok = ok && imported
+		return qual
+	})
+	return expr, ok
+}
+
+// zeroValue returns an expression for the zero value of the type, for
+// use in file.
+func zeroValue(file *ast.File, from *types.Package, t types.Type) (string, bool) {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsNumeric != 0:
+			return "0", true
+		case u.Info()&types.IsString != 0:
+			return `""`, true
+		case u.Info()&types.IsBoolean != 0:
+			return "false", true
+		default:
+			return "nil", true // unsafe.Pointer
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		if _, ok := t.(*types.TypeParam); !ok {
+			return "nil", true
+		}
+	case *types.Struct, *types.Array:
+		expr, ok := typeExpr(file, from, t)
+		return expr + "{}", ok
+	}
+	expr, ok := typeExpr(file, from, t)
+	return "*new(" + expr + ")", ok
+}
+
+// snakeCase converts a name in mixed caps, such as "HTTPStatusOK", to
+// snake case, such as "http_status_ok".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var buf strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && runes[i-1] != '_' {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				buf.WriteByte('_')
+			}
+		}
+		buf.WriteRune(unicode.ToLower(r))
+	}
+	return buf.String()
+}
+
+// indentAt returns the indentation of the line that pos is on, assuming
+// that the source is gofmt-ed and pos is at the start of the line's
+// content.
+func indentAt(fset *token.FileSet, pos token.Pos) string {
+	return strings.Repeat("\t", fset.Position(pos).Column-1)
+}
+
+// sameLine reports whether the positions are on the same line.
+func sameLine(fset *token.FileSet, x, y token.Pos) bool {
+	return fset.Position(x).Line == fset.Position(y).Line
+}
+
+// caseClauseInsertion returns the position at which to insert case
+// clauses into the body of the switch statement sw, the text to insert
+// before the clauses, and the indentation of the clauses. The clauses are
+// inserted before a trailing default clause, if any, to keep it last.
+func caseClauseInsertion(fset *token.FileSet, sw ast.Stmt, body *ast.BlockStmt) (pos token.Pos, prefix, indent string) {
+	var lastClause *ast.CaseClause
+	if n := len(body.List); n != 0 {
+		lastClause = body.List[n-1].(*ast.CaseClause)
+	}
+
+	indent = indentAt(fset, sw.Pos())
+	if lastClause != nil {
+		indent = indentAt(fset, lastClause.Pos())
+	}
+
+	if lastClause != nil && isDefaultCase(lastClause) {
+		return lastClause.Pos(), "", indent
+	}
+	if sameLine(fset, body.Lbrace, body.Rbrace) {
+		return body.Rbrace, "\n" + indent, indent
+	}
+	return body.Rbrace, "", indent
+}
+
+// removeCommentEdit returns an edit that removes the comment, which is
+// associated with node. If the comment is on a line before the node, the
+// entire line is removed.
+func removeCommentEdit(fset *token.FileSet, c *ast.Comment, node ast.Node) analysis.TextEdit {
+	tf := fset.File(c.Pos())
+	line := tf.Line(c.Pos())
+	if line < tf.Line(node.Pos()) {
+		end := token.Pos(tf.Base() + tf.Size())
+		if line < tf.LineCount() {
+			end = tf.LineStart(line + 1)
+		}
+		return analysis.TextEdit{Pos: tf.LineStart(line), End: end}
+	}
+	return analysis.TextEdit{Pos: c.Pos(), End: c.End()}
+}
+
+// nodeString returns the source text for the node.
+func nodeString(fset *token.FileSet, n ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, n); err != nil {
+		panic(err) // printing to a bytes.Buffer does not fail
+	}
+	return buf.String()
+}
+
+// hasSideEffects reports whether evaluating the expression may have side
+// effects. It is conservative: any function call, other than a type
+// conversion, and any receive operation is considered to have side
+// effects.
+func hasSideEffects(e ast.Expr, info *types.Info) bool {
+	var found bool
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if tv, ok := info.Types[n.Fun]; !ok || !tv.IsType() {
+				found = true
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.ARROW {
+				found = true
+			}
+		case *ast.FuncLit:
+			// The body isn't evaluated as part of the expression.
+			return false
+		}
+		return !found
+	})
+	return found
+}
+
+// unusedName returns a name, derived from base, that doesn't refer to
+// any object in the scope at pos.
+func unusedName(pkg *types.Package, pos token.Pos, base string) string {
+	scope := pkg.Scope().Innermost(pos)
+	if scope == nil {
+		scope = pkg.Scope()
+	}
+	name := base
+	for i := 1; ; i++ {
+		if _, obj := scope.LookupParent(name, pos); obj == nil {
+			return name
+		}
+		name = base + strconv.Itoa(i)
+	}
+}
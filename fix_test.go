@@ -0,0 +1,24 @@
+package exhaustive
+
+import "testing"
+
+func TestSnakeCase(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"a", "a"},
+		{"Tundra", "tundra"},
+		{"StatusNotFound", "status_not_found"},
+		{"HTTPStatus", "http_status"},
+		{"StatusHTTP", "status_http"},
+		{"localURLFormat", "local_url_format"},
+		{"Version2Beta", "version2_beta"},
+		{"KIND_A", "kind_a"},
+		{"Kind_Value", "kind_value"},
+	} {
+		if got := snakeCase(tt.in); got != tt.want {
+			t.Errorf("snakeCase(%q): got %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
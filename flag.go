@@ -1,13 +1,19 @@
 package exhaustive
 
 import (
+	"bytes"
 	"flag"
+	"fmt"
+	"go/parser"
 	"regexp"
 	"strings"
+	"text/template"
 )
 
 var _ flag.Value = (*regexpFlag)(nil)
 var _ flag.Value = (*stringsFlag)(nil)
+var _ flag.Value = (*templateFlag)(nil)
+var _ flag.Value = (*choiceFlag)(nil)
 
 // regexpFlag implements flag.Value for parsing
 // regular expression flag inputs.
@@ -73,3 +79,69 @@ func (f *stringsFlag) Set(input string) error {
 	}
 	return nil
 }
+
+// choiceFlag implements flag.Value for parsing a flag input that must be
+// one of a fixed set of choices. Surrounding whitespace is stripped from
+// the input.
+type choiceFlag struct {
+	value   string
+	choices []string
+}
+
+func (f *choiceFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+func (f *choiceFlag) Set(input string) error {
+	input = strings.TrimSpace(input)
+	for _, c := range f.choices {
+		if input == c {
+			f.value = input
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value %q (must be one of: %s)", input, strings.Join(f.choices, ", "))
+}
+
+// templateFlag implements flag.Value for parsing a text/template flag
+// input that expands to a list of Go statements. The template is
+// executed with a *fixData value. An empty input results in a nil
+// template.
+type templateFlag struct {
+	text string
+	tmpl *template.Template
+}
+
+func (f *templateFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.text
+}
+
+func (f *templateFlag) Set(text string) error {
+	if strings.TrimSpace(text) == "" {
+		f.text, f.tmpl = "", nil
+		return nil
+	}
+
+	tmpl, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return err
+	}
+	// Check that the template expands to valid statements, using
+	// placeholder data.
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, &fixData{tag: "v", Type: "T", Member: "A"}); err != nil {
+		return err
+	}
+	if _, err := parser.ParseExpr("func() {\n" + buf.String() + "\n}"); err != nil {
+		return fmt.Errorf("template does not expand to Go statements: %s", err)
+	}
+
+	f.text, f.tmpl = text, tmpl
+	return nil
+}
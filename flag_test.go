@@ -128,3 +128,111 @@ func TestStringsFlag(t *testing.T) {
 		}
 	})
 }
+
+func TestTemplateFlag(t *testing.T) {
+	t.Run("empty input", func(t *testing.T) {
+		var v templateFlag
+		if err := v.Set(" "); err != nil {
+			t.Errorf("error unexpectedly non-nil: %v", err)
+		}
+		if v.tmpl != nil {
+			t.Errorf("got %+v, want nil", v.tmpl)
+		}
+		if got := v.String(); got != "" {
+			t.Errorf("got %q, want empty string", got)
+		}
+	})
+
+	t.Run("bad template", func(t *testing.T) {
+		var v templateFlag
+		if err := v.Set("panic({{.Tag)"); err == nil {
+			t.Errorf("error unexpectedly nil")
+		}
+		if v.tmpl != nil {
+			t.Errorf("got %+v, want nil", v.tmpl)
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		var v templateFlag
+		if err := v.Set("panic({{.Foo}})"); err == nil {
+			t.Errorf("error unexpectedly nil")
+		}
+	})
+
+	t.Run("not statements", func(t *testing.T) {
+		var v templateFlag
+		if err := v.Set("case {{.Member}}:"); err == nil {
+			t.Errorf("error unexpectedly nil")
+		}
+		if v.tmpl != nil {
+			t.Errorf("got %+v, want nil", v.tmpl)
+		}
+	})
+
+	t.Run("good input", func(t *testing.T) {
+		var v templateFlag
+		input := `panic(fmt.Sprintf("unexpected %v: %v", "{{.Member}}", {{.Tag}}))`
+		if err := v.Set(input); err != nil {
+			t.Errorf("error unexpectedly non-nil: %v", err)
+		}
+		if v.tmpl == nil {
+			t.Errorf("unexpectedly nil")
+		}
+		if got := v.String(); got != input {
+			t.Errorf("got %q, want %q", got, input)
+		}
+	})
+
+	t.Run("String nil receiver", func(t *testing.T) {
+		var v *templateFlag
+		if got := v.String(); got != "" {
+			t.Errorf("got %q, want empty string", got)
+		}
+	})
+}
+
+func TestChoiceFlag(t *testing.T) {
+	newFlag := func() choiceFlag {
+		return choiceFlag{value: "a", choices: []string{"a", "bb"}}
+	}
+
+	t.Run("not set", func(t *testing.T) {
+		v := newFlag()
+		if got := v.String(); got != "a" {
+			t.Errorf("got %q, want %q", got, "a")
+		}
+	})
+
+	t.Run("valid choice", func(t *testing.T) {
+		v := newFlag()
+		if err := v.Set(" bb "); err != nil {
+			t.Errorf("error unexpectedly non-nil: %v", err)
+		}
+		if got := v.String(); got != "bb" {
+			t.Errorf("got %q, want %q", got, "bb")
+		}
+	})
+
+	t.Run("invalid choice", func(t *testing.T) {
+		v := newFlag()
+		err := v.Set("b")
+		if err == nil {
+			t.Errorf("error unexpectedly nil")
+			return
+		}
+		if got, want := err.Error(), `invalid value "b" (must be one of: a, bb)`; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+		if got := v.String(); got != "a" {
+			t.Errorf("got %q, want %q", got, "a")
+		}
+	})
+
+	t.Run("String nil receiver", func(t *testing.T) {
+		var v *choiceFlag
+		if got := v.String(); got != "" {
+			t.Errorf("got %q, want empty string", got)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package exhaustive
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// A bit-flag enum is an enum whose members are bit flags, such as
+//
+//	type Perm int
+//
+//	const (
+//		Read Perm = 1 << iota
+//		Write
+//		Exec
+//	)
+//
+// An enum is a bit-flag enum if it is declared with an
+// "//exhaustive:flags" comment. Only the single-bit members of a bit-flag
+// enum have to be accounted for, and an expression accounts for each
+// single-bit member whose bit it has.
+
+// flagsValuePrefix is the prefix of a flagsValue.
+const flagsValuePrefix = "|"
+
+// flagsValue returns the value that represents a constant expression that
+// combines bit flags, such as A|B, or the mask of a bit test, such as M in
+// v&M != 0. Unlike the value of a member constant, such a value accounts
+// only for the members of bit-flag enums.
+func flagsValue(v constant.Value) constantValue {
+	return constantValue(flagsValuePrefix + v.ExactString())
+}
+
+// isFlagsValue reports whether val was returned by flagsValue.
+func isFlagsValue(val constantValue) bool {
+	return strings.HasPrefix(string(val), flagsValuePrefix)
+}
+
+// flagBits returns the bits of the value, which is either a flagsValue or
+// the value of a constant. The ok return value is false if the value
+// isn't a non-negative integer that fits in 64 bits.
+func flagBits(val constantValue) (bits uint64, ok bool) {
+	v := constant.MakeFromLiteral(strings.TrimPrefix(string(val), flagsValuePrefix), token.INT, 0)
+	if v.Kind() != constant.Int {
+		return 0, false
+	}
+	return constant.Uint64Val(v)
+}
+
+func isSingleBit(bits uint64) bool {
+	return bits != 0 && bits&(bits-1) == 0
+}
+
+// isFlagMember reports whether the named member of the bit-flag enum is a
+// single-bit member, and returns its bit.
+func isFlagMember(em enumMembers, name string) (bit uint64, ok bool) {
+	bit, ok = flagBits(em.NameToValue[name])
+	return bit, ok && isSingleBit(bit)
+}
+
+// flagsMask returns the mask of the expression if it is of the form x&M,
+// where M is a constant.
+func flagsMask(e ast.Expr, info *types.Info) (uint64, bool) {
+	bin, ok := astutil.Unparen(e).(*ast.BinaryExpr)
+	if !ok || bin.Op != token.AND {
+		return 0, false
+	}
+	for _, m := range []ast.Expr{bin.X, bin.Y} {
+		if tv, ok := info.Types[m]; ok && tv.Value != nil {
+			return flagBits(flagsValue(tv.Value))
+		}
+	}
+	return 0, false
+}
+
+// allFlagEnums reports whether each of the enums is a bit-flag enum.
+func allFlagEnums(es []enumTypeAndMembers) bool {
+	for _, e := range es {
+		if !e.members.Flags {
+			return false
+		}
+	}
+	return true
+}
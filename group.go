@@ -0,0 +1,183 @@
+package exhaustive
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// A named group is a subset of the members of an enum type, declared with
+// a "//exhaustive:group" comment on the type, as in:
+//
+//	//exhaustive:group terminal Done,Failed,Cancelled
+//	type State int
+//
+// A switch statement associated with an "//exhaustive:enforce
+// group=terminal" comment has to list exactly the members of the group.
+
+// groupArgPrefix is the prefix of the argument of an enforce directive
+// that names a group.
+const groupArgPrefix = "group="
+
+// findGroups records, in the Groups field of the members of each enum
+// type, the named groups declared on the type, and reports invalid group
+// directives.
+func findGroups(pass *analysis.Pass, inspect *inspector.Inspector, enums map[enumType]enumMembers) {
+	inspect.Preorder([]ast.Node{&ast.GenDecl{}}, func(n ast.Node) {
+		gen := n.(*ast.GenDecl)
+		if gen.Tok != token.TYPE {
+			return
+		}
+		for _, s := range gen.Specs {
+			t := s.(*ast.TypeSpec)
+			groups := []*ast.CommentGroup{gen.Doc, t.Doc}
+			tn, ok := pass.TypesInfo.Defs[t.Name].(*types.TypeName)
+			if !ok {
+				continue
+			}
+			et := enumType{tn}
+			em, isEnum := enums[et]
+			for _, c := range groupComments(groups) {
+				if !isEnum {
+					pass.Report(makeInvalidDirectiveDiagnostic(c, fmt.Errorf("type %s is not an enum type", tn.Name())))
+					break
+				}
+				name, names, err := parseGroup(c, em)
+				if err != nil {
+					pass.Report(makeInvalidDirectiveDiagnostic(c, err))
+					continue
+				}
+				if _, ok := em.Groups[name]; ok {
+					pass.Report(makeInvalidDirectiveDiagnostic(c, fmt.Errorf("duplicate group %q", name)))
+					continue
+				}
+				if em.Groups == nil {
+					em.Groups = make(map[string][]string)
+				}
+				em.Groups[name] = names
+			}
+			if isEnum {
+				enums[et] = em
+			}
+		}
+	})
+}
+
+// groupComments returns the group directives in the comment groups.
+func groupComments(commentGroups []*ast.CommentGroup) []*ast.Comment {
+	var out []*ast.Comment
+	for _, commentGroup := range commentGroups {
+		if commentGroup == nil {
+			continue
+		}
+		for _, comment := range commentGroup.List {
+			if directive, _, ok := splitDirective(comment.Text); ok && directive == groupComment {
+				out = append(out, comment)
+			}
+		}
+	}
+	return out
+}
+
+// parseGroup parses the group directive, of the form
+// "//exhaustive:group name A,B,C", for the enum members em. The list of
+// members may have spaces after or before its commas, as in
+// "//exhaustive:group name A, B, C". Any text after the list of members
+// is ignored.
+func parseGroup(c *ast.Comment, em enumMembers) (name string, names []string, err error) {
+	_, args, _ := splitDirective(c.Text)
+	fields := strings.Fields(args)
+	if len(fields) < 2 {
+		return "", nil, fmt.Errorf("%q directive requires a group name and a comma-separated list of members", groupComment)
+	}
+	name = fields[0]
+	list := fields[1]
+	for _, f := range fields[2:] {
+		if !strings.HasSuffix(list, ",") && !strings.HasPrefix(f, ",") {
+			break
+		}
+		list += f
+	}
+	for _, m := range strings.Split(list, ",") {
+		if _, ok := em.NameToValue[m]; !ok {
+			return "", nil, fmt.Errorf("%s in group %q is not an enum member", m, name)
+		}
+		names = append(names, m)
+	}
+	return name, names, nil
+}
+
+// switchGroup returns the name of the group in the enforce directive in
+// the comment groups, if any.
+func switchGroup(commentGroups []*ast.CommentGroup) (string, bool) {
+	for _, args := range directiveArgs(commentGroups, enforceComment) {
+		for _, f := range strings.Fields(args) {
+			if strings.HasPrefix(f, groupArgPrefix) {
+				return strings.TrimPrefix(f, groupArgPrefix), true
+			}
+		}
+	}
+	return "", false
+}
+
+// inGroup reports whether the named member of the enum is in the group.
+func inGroup(em enumMembers, group, name string) bool {
+	for _, n := range em.Groups[group] {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGroup reports whether any of the enums declares the group.
+func hasGroup(es []enumTypeAndMembers, group string) bool {
+	for _, e := range es {
+		if _, ok := e.members.Groups[group]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// restrictGroup removes the members that aren't in the group, so that
+// they need not be accounted for.
+func (c *checklist) restrictGroup(group string) {
+	for m := range c.checkl {
+		if !inGroup(c.info[m.typ], group, m.name) {
+			delete(c.checkl, m)
+		}
+	}
+}
+
+// reportOutsideGroup reports each case expression of the switch statement
+// that has the value of an enum member, but not the value of a member of
+// the group.
+func reportOutsideGroup(sw *ast.SwitchStmt, info *types.Info, es []enumTypeAndMembers, group string, report func(analysis.Diagnostic)) {
+	for _, e := range switchCaseExprs(sw) {
+		val, ok := exprConstVal(e, info)
+		if !ok || isFlagsValue(val) {
+			continue
+		}
+		var member, grouped bool
+		for _, et := range es {
+			for _, name := range et.members.ValueToNames[val] {
+				member = true
+				grouped = grouped || inGroup(et.members, group, name)
+			}
+		}
+		if member && !grouped {
+			report(analysis.Diagnostic{
+				Pos:      e.Pos(),
+				End:      e.End(),
+				Category: CategoryOutsideGroup,
+				Message:  fmt.Sprintf("case %s is not in group %q of enum type %s", types.ExprString(e), group, diagnosticEnumTypes(dedupEnumTypes(toEnumTypes(es)))),
+			})
+		}
+	}
+}
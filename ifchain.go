@@ -0,0 +1,345 @@
+package exhaustive
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// ifChainConfig is configuration for ifChainChecker.
+type ifChainConfig struct {
+	explicit                   bool
+	defaultSignifiesExhaustive bool
+	checkGenerated             bool
+	ignoreConstant             *regexp.Regexp // can be nil
+	ignoreType                 *regexp.Regexp // can be nil
+	reportUnnecessaryIgnore    bool
+	requireDeprecated          bool
+}
+
+// ifChainChecker returns a node visitor that checks exhaustiveness of
+// if-else chains that compare an enum value against enum members, for
+// the supplied pass, and reports diagnostics. The node visitor expects
+// only *ast.IfStmt nodes.
+//
+// An if-else chain is checked if it has at least one "else if", and if
+// each of its conditions is an equality comparison, or a disjunction of
+// equality comparisons, between the same operand and enum members, as in:
+//
+//	if k == A {
+//	} else if k == B || k == C {
+//	} else {
+//	}
+//
+// The final else, if any, is treated like a default case in a switch
+// statement.
+func ifChainChecker(pass *analysis.Pass, cfg ifChainConfig, generated boolCache, comments commentCache) nodeVisitor {
+	return func(n ast.Node, push bool, stack []ast.Node) (bool, string) {
+		if !push {
+			return true, resultNotPush
+		}
+
+		file := stack[0].(*ast.File)
+
+		if !cfg.checkGenerated && generated.get(file) {
+			return false, resultGeneratedFile
+		}
+
+		ifStmt := n.(*ast.IfStmt)
+
+		if len(stack) >= 2 {
+			if parent, ok := stack[len(stack)-2].(*ast.IfStmt); ok && parent.Else == ifStmt {
+				// Checked as part of the chain that parent begins.
+				return true, resultElseIf
+			}
+		}
+
+		operand, conds, hasElse, ok := analyzeIfChain(ifStmt, pass.TypesInfo)
+		if !ok {
+			return true, resultNotIfChain
+		}
+
+		ifComments := comments.get(pass.Fset, file)[ifStmt]
+		directives, err := parseDirectives(ifComments)
+		if err == nil {
+			err = directives.validateUse("an if-else chain", 0)
+		}
+		if err != nil {
+			pass.Report(makeInvalidDirectiveDiagnostic(ifStmt, err))
+		}
+
+		ignored := !cfg.explicit && directives.has(ignoreDirective)
+		if ignored && !cfg.reportUnnecessaryIgnore {
+			return true, resultIgnoreComment
+		}
+		if cfg.explicit && !directives.has(enforceDirective) {
+			return true, resultNoEnforceComment
+		}
+
+		check := func(report func(analysis.Diagnostic)) string {
+			return checkEqualityComparisons(pass, operand, conds, hasElse, cfg.defaultSignifiesExhaustive, cfg.ignoreConstant, cfg.ignoreType, cfg.requireDeprecated, func(enumTypes []enumType, missing map[member]struct{}) {
+				report(makeIfChainDiagnostic(ifStmt, enumTypes, missing))
+			})
+		}
+
+		if ignored {
+			var wouldReport bool
+			result := check(func(analysis.Diagnostic) { wouldReport = true })
+			if wouldReport || !isExhaustiveResult(result) {
+				return true, resultIgnoreComment
+			}
+			pass.Report(makeUnnecessaryIgnoreDiagnostic(pass.Fset, ifStmt, ifComments))
+			return true, resultUnnecessaryIgnore
+		}
+		return true, check(pass.Report)
+	}
+}
+
+// analyzeIfChain returns the operand that the conditions of the if-else
+// chain that begins with ifStmt compare against enum members, the
+// conditions, and whether the chain ends with an else. The ok return
+// value is false if the chain is not of the form described in
+// ifChainChecker.
+func analyzeIfChain(ifStmt *ast.IfStmt, info *types.Info) (operand ast.Expr, conds []ast.Expr, hasElse bool, ok bool) {
+	for s := ifStmt; s != nil; {
+		conds = append(conds, s.Cond)
+		switch e := s.Else.(type) {
+		case *ast.IfStmt:
+			s = e
+		case *ast.BlockStmt:
+			hasElse = true
+			s = nil
+		default:
+			s = nil
+		}
+	}
+	if len(conds) < 2 {
+		return nil, nil, false, false
+	}
+	operand, ok = equalityOperand(conds, info, true)
+	return operand, conds, hasElse, ok
+}
+
+// equalityOperand returns the operand that each of the expressions
+// compares against constants. Each expression must be an equality
+// comparison or bit test, or a disjunction of them, between the same
+// operand and constant identifiers, as accepted by splitComparison;
+// otherwise the ok return value is false. If vars is true, package-level
+// variables are accepted as constant identifiers; see exprMemberVal.
+func equalityOperand(exprs []ast.Expr, info *types.Info, vars bool) (operand ast.Expr, ok bool) {
+	var visit func(e ast.Expr) bool
+	visit = func(e ast.Expr) bool {
+		e = astutil.Unparen(e)
+		bin, ok := e.(*ast.BinaryExpr)
+		if !ok {
+			return false
+		}
+		switch bin.Op {
+		case token.LOR:
+			return visit(bin.X) && visit(bin.Y)
+		case token.EQL, token.NEQ, token.GTR:
+			x, _, _, ok := splitComparison(bin, info, vars)
+			if !ok {
+				return false
+			}
+			if operand == nil {
+				operand = x
+				return true
+			}
+			return sameOperand(operand, x, info)
+		default:
+			return false
+		}
+	}
+	for _, e := range exprs {
+		if !visit(e) {
+			return nil, false
+		}
+	}
+	return operand, true
+}
+
+// analyzeEqualityComparisons calls each for the constant value of each
+// constant that the expressions, which satisfy equalityOperand, compare
+// their operand against.
+func analyzeEqualityComparisons(exprs []ast.Expr, info *types.Info, vars bool, each func(constantValue)) {
+	visitEqualityComparisons(exprs, info, vars, func(_ ast.Expr, val constantValue) { each(val) })
+}
+
+// comparedConstants returns the constants that the expressions, which
+// satisfy equalityOperand, compare their operand against.
+func comparedConstants(exprs []ast.Expr, info *types.Info, vars bool) []ast.Expr {
+	var out []ast.Expr
+	visitEqualityComparisons(exprs, info, vars, func(c ast.Expr, _ constantValue) { out = append(out, c) })
+	return out
+}
+
+// visitEqualityComparisons calls each for each constant, and its value,
+// that the expressions, which satisfy equalityOperand, compare their
+// operand against.
+func visitEqualityComparisons(exprs []ast.Expr, info *types.Info, vars bool, each func(c ast.Expr, val constantValue)) {
+	var visit func(e ast.Expr)
+	visit = func(e ast.Expr) {
+		bin := astutil.Unparen(e).(*ast.BinaryExpr)
+		if bin.Op == token.LOR {
+			visit(bin.X)
+			visit(bin.Y)
+			return
+		}
+		if _, c, val, ok := splitComparison(bin, info, vars); ok {
+			each(c, val)
+		}
+	}
+	for _, e := range exprs {
+		visit(e)
+	}
+}
+
+// hasFlagsComparison reports whether any of the expressions, which
+// satisfy equalityOperand, is a bit test or compares against a
+// combination of bit flags. Such comparisons are meaningful only for
+// bit-flag enums.
+func hasFlagsComparison(exprs []ast.Expr, info *types.Info, vars bool) bool {
+	var has bool
+	analyzeEqualityComparisons(exprs, info, vars, func(val constantValue) {
+		has = has || isFlagsValue(val)
+	})
+	return has
+}
+
+// splitComparison returns the non-constant operand x of the comparison,
+// and the other operand c, which must be a constant identifier as defined
+// by exprMemberVal, along with its constant value. The comparison is
+// either an equality comparison, x == c, or a bit test, such as x&c != 0,
+// x&c == c, or x&c > 0; the value of the mask c of a bit test is a
+// flagsValue.
+func splitComparison(bin *ast.BinaryExpr, info *types.Info, vars bool) (x, c ast.Expr, val constantValue, ok bool) {
+	isConst := func(e ast.Expr) bool {
+		tv, ok := info.Types[e]
+		return ok && tv.Value != nil
+	}
+
+	// Bit tests.
+	for _, pair := range [][2]ast.Expr{{bin.X, bin.Y}, {bin.Y, bin.X}} {
+		and, ok := astutil.Unparen(pair[0]).(*ast.BinaryExpr)
+		if !ok || and.Op != token.AND || !isConst(pair[1]) {
+			continue
+		}
+		switch bin.Op {
+		case token.EQL, token.NEQ, token.GTR:
+		default:
+			return nil, nil, "", false
+		}
+		if _, ok := exprConstVal(and.Y, info); ok && !isConst(and.X) {
+			return astutil.Unparen(and.X), and.Y, flagsValue(info.Types[and.Y].Value), true
+		}
+		if _, ok := exprConstVal(and.X, info); ok && !isConst(and.Y) {
+			return astutil.Unparen(and.Y), and.X, flagsValue(info.Types[and.X].Value), true
+		}
+		return nil, nil, "", false
+	}
+
+	if bin.Op != token.EQL {
+		return nil, nil, "", false
+	}
+	if val, ok := exprMemberVal(bin.Y, info, vars); ok && !isConst(bin.X) {
+		return astutil.Unparen(bin.X), bin.Y, val, true
+	}
+	if val, ok := exprMemberVal(bin.X, info, vars); ok && !isConst(bin.Y) {
+		return astutil.Unparen(bin.Y), bin.X, val, true
+	}
+	return nil, nil, "", false
+}
+
+// sameOperand reports whether the expressions denote the same variable.
+// Only identifiers and selector expressions, such as v, pkg.V, and v.f.g,
+// are supported, so that evaluating the expressions has no side effects.
+func sameOperand(x, y ast.Expr, info *types.Info) bool {
+	x, y = astutil.Unparen(x), astutil.Unparen(y)
+	switch x := x.(type) {
+	case *ast.Ident:
+		y, ok := y.(*ast.Ident)
+		return ok && info.ObjectOf(x) != nil && info.ObjectOf(x) == info.ObjectOf(y)
+	case *ast.SelectorExpr:
+		y, ok := y.(*ast.SelectorExpr)
+		if !ok || info.ObjectOf(x.Sel) == nil || info.ObjectOf(x.Sel) != info.ObjectOf(y.Sel) {
+			return false
+		}
+		if xIdent, ok := x.X.(*ast.Ident); ok && denotesPackage(xIdent, info) {
+			// pkg.V; the package is determined by the object.
+			return true
+		}
+		return sameOperand(x.X, y.X, info)
+	default:
+		return false
+	}
+}
+
+// checkEqualityComparisons checks the exhaustiveness of the equality
+// comparisons of operand in exprs, which satisfy equalityOperand. The
+// hasDefault parameter indicates whether a default case, such as a final
+// else, follows the comparisons. If members are missing, report is called.
+// It returns the result of the check.
+func checkEqualityComparisons(pass *analysis.Pass, operand ast.Expr, exprs []ast.Expr, hasDefault, defaultSignifiesExhaustive bool, ignoreConstant, ignoreType *regexp.Regexp, requireDeprecated bool, report func([]enumType, map[member]struct{})) string {
+	t := pass.TypesInfo.TypeOf(operand)
+	if t == nil {
+		return resultEnumTypes
+	}
+	es, ok := composingEnumTypes(pass, t)
+	if !ok || len(es) == 0 {
+		es = varEnumsReferenced(pass, comparedConstants(exprs, pass.TypesInfo, true))
+		if len(es) == 0 {
+			return resultEnumTypes
+		}
+	}
+	vars := hasVarEnum(es)
+	if _, ok := equalityOperand(exprs, pass.TypesInfo, vars); !ok {
+		// A comparison against a variable, for an enum that isn't a
+		// variable enum.
+		return resultNotIfChain
+	}
+
+	if !allFlagEnums(es) && hasFlagsComparison(exprs, pass.TypesInfo, vars) {
+		return resultNotFlagEnum
+	}
+
+	var checkl checklist
+	checkl.ignoreConstant(ignoreConstant)
+	checkl.ignoreType(ignoreType)
+	checkl.requireDeprecated(requireDeprecated)
+
+	for _, e := range es {
+		checkl.add(e.typ, e.members, pass.Pkg == e.typ.Pkg())
+	}
+
+	analyzeEqualityComparisons(exprs, pass.TypesInfo, vars, checkl.found)
+	if len(checkl.remaining()) == 0 {
+		return resultEnumMembersAccounted
+	}
+	if hasDefault && defaultSignifiesExhaustive {
+		return resultDefaultCaseSuffices
+	}
+	report(dedupEnumTypes(toEnumTypes(es)), checkl.remaining())
+	return resultReportedDiagnostic
+}
+
+func makeIfChainDiagnostic(ifStmt *ast.IfStmt, enumTypes []enumType, missing map[member]struct{}) analysis.Diagnostic {
+	names := diagnosticGroupNames(groupify(missing, enumTypes))
+	return analysis.Diagnostic{
+		Pos:      ifStmt.Pos(),
+		End:      ifStmt.End(),
+		Category: CategoryMissingCases,
+		Message: fmt.Sprintf(
+			"missing cases in if-else chain of type %s: %s",
+			diagnosticEnumTypes(enumTypes),
+			strings.Join(names, ", "),
+		),
+		Related: missingRelated(ifStmt, names),
+	}
+}
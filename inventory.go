@@ -0,0 +1,169 @@
+package exhaustive
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// InventoryAnalyzer finds the enums declared in a package, in the same way
+// that Analyzer does, and returns them as its result, of type []Enum. The
+// enums include variable enums and the extension members declared in the
+// package. It reports no diagnostics; invalid directives, for example, are
+// reported by Analyzer. It is configured by the flags of Analyzer, such as
+// -package-scope-only, -enum-discovery, -ignore-enum-types, and
+// -ignore-enum-members, so that the enums that it finds match those that
+// Analyzer enforces.
+var InventoryAnalyzer = &analysis.Analyzer{
+	Name:       "exhaustiveenums",
+	Doc:        "find the enums declared in a package",
+	Run:        runInventory,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	ResultType: reflect.TypeOf([]Enum(nil)),
+}
+
+// An Enum is an enum type and its members.
+//
+// For a variable enum declared on a var declaration, Type is a type name,
+// not declared in any scope, whose name is that of the type of the first
+// member. For the extension members declared in a package, Type is the
+// enum type, which is declared in another package, and Members are the
+// extension members.
+type Enum struct {
+	Type      *types.TypeName
+	Doc       string // doc comment of the type declaration, or of the var declaration of a variable enum declared on one
+	Flags     bool   // whether the enum is a bit-flag enum
+	Ignored   bool   // whether the type matches the -ignore-enum-types flag
+	Vars      bool   // whether the enum is a variable enum, whose members are package-level variables
+	Extension bool   // whether the members are extension members of an enum type in another package
+	Members   []EnumMember
+}
+
+// An EnumMember is a member of an enum type.
+type EnumMember struct {
+	Name       string
+	Value      string // constant value, in the form of constant.Value.ExactString; empty for a variable
+	Pos        token.Pos
+	Doc        string // doc comment of the constant or variable declaration
+	Ignored    bool   // whether the member matches the -ignore-enum-members flag
+	Sentinel   bool   // whether the member matches the -sentinel-pattern flag
+	Optional   bool   // whether the member is an unspecified protobuf value
+	Deprecated bool   // whether the member is deprecated
+	Constraint string // build constraint of the member's file, if recorded
+}
+
+func runInventory(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Enum discovery reports invalid directives, which Analyzer reports
+	// instead.
+	p := *pass
+	p.Report = func(analysis.Diagnostic) {}
+	pass = &p
+
+	typeDocs := make(map[token.Pos]string)  // type name position -> doc comment
+	constDocs := make(map[token.Pos]string) // constant or variable name position -> doc comment
+	varDocs := make(map[token.Pos]string)   // first variable name position -> doc comment of the var declaration
+	inspect.Preorder([]ast.Node{&ast.GenDecl{}}, func(n ast.Node) {
+		gen := n.(*ast.GenDecl)
+		for i, s := range gen.Specs {
+			switch s := s.(type) {
+			case *ast.TypeSpec:
+				typeDocs[s.Name.Pos()] = specDoc(gen, s.Doc)
+			case *ast.ValueSpec:
+				for j, name := range s.Names {
+					constDocs[name.Pos()] = specDoc(gen, s.Doc)
+					if gen.Tok == token.VAR && i == 0 && j == 0 && gen.Doc != nil {
+						varDocs[name.Pos()] = strings.TrimSpace(gen.Doc.Text())
+					}
+				}
+			}
+		}
+	})
+
+	makeEnum := func(et enumType, em enumMembers, doc string) Enum {
+		e := Enum{
+			Type:    et.TypeName,
+			Doc:     doc,
+			Flags:   em.Flags,
+			Ignored: reMatch(fIgnoreEnumTypes.re, typeKey(et.declared())),
+		}
+		for _, name := range em.Names {
+			_, deprecated := em.Deprecated[name]
+			e.Members = append(e.Members, EnumMember{
+				Name:       name,
+				Value:      string(em.NameToValue[name]),
+				Pos:        em.NameToPos[name],
+				Doc:        constDocs[em.NameToPos[name]],
+				Ignored:    reMatch(fIgnoreEnumMembers.re, pass.Pkg.Path()+"."+name),
+				Sentinel:   em.Sentinels[name],
+				Optional:   em.Optional[name],
+				Deprecated: deprecated,
+				Constraint: em.Constraints[name],
+			})
+		}
+		return e
+	}
+	makeVarEnum := func(et enumType, em enumMembers, doc string) Enum {
+		e := makeEnum(et, em, doc)
+		e.Vars = true
+		for i := range e.Members {
+			e.Members[i].Value = ""
+		}
+		return e
+	}
+
+	var result []Enum
+	enums := discoverEnums(pass, inspect)
+	for et, em := range enums {
+		result = append(result, makeEnum(et, em, typeDocs[et.Pos()]))
+	}
+	typeEnums, blockEnums := findVarEnums(pass.Pkg, inspect, pass.TypesInfo, enums)
+	for et, em := range typeEnums {
+		result = append(result, makeVarEnum(et, em, typeDocs[et.Pos()]))
+	}
+	for _, b := range blockEnums {
+		first := b.vars[0]
+		name := types.TypeString(first.Type(), types.RelativeTo(first.Pkg()))
+		tn := types.NewTypeName(first.Pos(), first.Pkg(), name, first.Type())
+		result = append(result, makeVarEnum(enumType{tn}, b.members, varDocs[first.Pos()]))
+	}
+	for tn, em := range findExtensionMembers(pass, inspect) {
+		e := makeEnum(enumType{tn}, em, "")
+		e.Extension = true
+		result = append(result, e)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].pos() < result[j].pos()
+	})
+	return result, nil
+}
+
+// pos returns the position of the declaration of the enum in the package:
+// that of its type or, for extension members, of the first member.
+func (e Enum) pos() token.Pos {
+	if e.Extension && len(e.Members) != 0 {
+		return e.Members[0].Pos
+	}
+	return e.Type.Pos()
+}
+
+// specDoc returns the text of the doc comment of a spec, or of its
+// declaration if the declaration isn't parenthesized.
+func specDoc(gen *ast.GenDecl, doc *ast.CommentGroup) string {
+	if doc == nil && !gen.Lparen.IsValid() {
+		doc = gen.Doc
+	}
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.Text())
+}
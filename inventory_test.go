@@ -0,0 +1,69 @@
+package exhaustive
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestInventoryAnalyzer(t *testing.T) {
+	resetFlags()
+	defer resetFlags()
+	fIgnoreEnumTypes = regexpFlag{regexp.MustCompile(`inventory\.Shape`)}
+	fSentinelPattern = regexpFlag{regexp.MustCompile(testSentinelPattern)}
+
+	results := analysistest.Run(t, analysistest.TestData(), InventoryAnalyzer, "inventory")
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	enums := results[0].Result.([]Enum)
+
+	type memberSummary struct {
+		Name, Value, Doc              string
+		Sentinel, Deprecated, Ignored bool
+	}
+	type enumSummary struct {
+		Name, Doc                string
+		Ignored, Vars, Extension bool
+		Members                  []memberSummary
+	}
+	var got []enumSummary
+	for _, e := range enums {
+		s := enumSummary{Name: e.Type.Name(), Doc: e.Doc, Ignored: e.Ignored, Vars: e.Vars, Extension: e.Extension}
+		for _, m := range e.Members {
+			s.Members = append(s.Members, memberSummary{m.Name, m.Value, m.Doc, m.Sentinel, m.Deprecated, m.Ignored})
+		}
+		got = append(got, s)
+	}
+
+	want := []enumSummary{
+		{"Color", "Color is a color.", false, false, false, []memberSummary{
+			{"Red", "0", "Red is red.", false, false, false},
+			{"Green", "1", "", false, false, false},
+			{"Lime", "2", "Deprecated: Use Green instead.", false, true, false},
+			{"numColors", "3", "", true, false, false},
+		}},
+		{"Shape", "", true, false, false, []memberSummary{
+			{"Circle", `"circle"`, "", false, false, false},
+		}},
+		{"Typo", "Invalid directives are reported by Analyzer, not InventoryAnalyzer.", false, false, false, []memberSummary{
+			{"TypoA", "0", "", false, false, false},
+		}},
+		{"Status", "Status is a status.", false, true, false, []memberSummary{
+			{"OK", "", "OK is ok.", false, false, false},
+			{"Error", "", "", false, false, false},
+		}},
+		{"error", "Errors are the errors.", false, true, false, []memberSummary{
+			{"ErrA", "", "", false, false, false},
+			{"ErrB", "", "", false, false, false},
+		}},
+		{"Kind", "", false, false, true, []memberSummary{
+			{"Custom", "100", "Custom is a kind.", false, false, false},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -5,18 +5,36 @@ import (
 	"go/ast"
 	"go/types"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 )
 
 // mapConfig is configuration for mapChecker.
 type mapConfig struct {
-	explicit       bool
-	checkGenerated bool
-	ignoreConstant *regexp.Regexp // can be nil
-	ignoreType     *regexp.Regexp // can be nil
+	explicit                bool
+	checkGenerated          bool
+	ignoreConstant          *regexp.Regexp // can be nil
+	ignoreType              *regexp.Regexp // can be nil
+	fixValue                string         // one of the mapValue* constants
+	reportUnnecessaryIgnore bool
+	productKeys             bool
+	matchValues             bool
+	reportMemberValues      bool
+	requireDeprecated       bool
 }
 
+// Values for the -fix-map-value flag.
+const (
+	mapValueZero  = "zero"  // zero value of the element type
+	mapValueName  = "name"  // member name, e.g. "HTTPStatus"
+	mapValueLower = "lower" // member name in lower case, e.g. "httpstatus"
+	mapValueSnake = "snake" // member name in snake case, e.g. "http_status"
+)
+
+var mapValueChoices = []string{mapValueZero, mapValueName, mapValueLower, mapValueSnake}
+
 // mapChecker returns a node visitor that checks for exhaustiveness of
 // map literals for the supplied pass, and reports diagnostics. The
 // node visitor expects only *ast.CompositeLit nodes.
@@ -34,16 +52,9 @@ func mapChecker(pass *analysis.Pass, cfg mapConfig, generated boolCache, comment
 
 		lit := n.(*ast.CompositeLit)
 
-		mapType, ok := pass.TypesInfo.Types[lit.Type].Type.(*types.Map)
+		mapType, ok := mapLiteralType(lit, pass.TypesInfo)
 		if !ok {
-			namedType, ok2 := pass.TypesInfo.Types[lit.Type].Type.(*types.Named)
-			if !ok2 {
-				return true, resultNotMapLiteral
-			}
-			mapType, ok = namedType.Underlying().(*types.Map)
-			if !ok {
-				return true, resultNotMapLiteral
-			}
+			return true, resultNotMapLiteral
 		}
 
 		if len(lit.Elts) == 0 {
@@ -51,38 +62,19 @@ func mapChecker(pass *analysis.Pass, cfg mapConfig, generated boolCache, comment
 		}
 
 		fileComments := comments.get(pass.Fset, file)
-		var relatedComments []*ast.CommentGroup
-		for i := range stack {
-			// iterate over stack in the reverse order (from inner
-			// node to outer node)
-			node := stack[len(stack)-1-i]
-			switch node.(type) {
-			// need to check comments associated with following nodes,
-			// because logic of ast package doesn't associate comment
-			// with *ast.CompositeLit as required.
-			case *ast.CompositeLit, // stack[len(stack)-1]
-				*ast.ReturnStmt, // return ...
-				*ast.IndexExpr,  // map[enum]...{...}[key]
-				*ast.CallExpr,   // myfunc(map...)
-				*ast.UnaryExpr,  // &map...
-				*ast.AssignStmt, // variable assignment (without var keyword)
-				*ast.DeclStmt,   // var declaration, parent of *ast.GenDecl
-				*ast.GenDecl,    // var declaration, parent of *ast.ValueSpec
-				*ast.ValueSpec:  // var declaration
-				relatedComments = append(relatedComments, fileComments[node]...)
-				continue
-			default:
-				// stop iteration on the first inappropriate node
-				break
-			}
-		}
-
+		relatedComments := compositeLitComments(fileComments, stack)
 		directives, err := parseDirectives(relatedComments)
+		if err == nil {
+			// Exclude directives list the combinations of product keys
+			// that need not be present; see checkProductKeys.
+			err = directives.validateUse("a map literal", excludeDirective)
+		}
 		if err != nil {
 			pass.Report(makeInvalidDirectiveDiagnostic(lit, err))
 		}
 
-		if !cfg.explicit && directives.has(ignoreDirective) {
+		ignored := !cfg.explicit && directives.has(ignoreDirective)
+		if ignored && !cfg.reportUnnecessaryIgnore {
 			// Skip checking of this map literal due to ignore
 			// comment. Still return true because there may be nested
 			// map literals that are not to be ignored.
@@ -92,26 +84,180 @@ func mapChecker(pass *analysis.Pass, cfg mapConfig, generated boolCache, comment
 			return true, resultNoEnforceComment
 		}
 
-		es, ok := composingEnumTypes(pass, mapType.Key())
-		if !ok || len(es) == 0 {
-			return true, resultEnumTypes
+		var product *productKeys
+		if cfg.productKeys {
+			product = analyzeProductKeys(pass, cfg, lit, mapType)
+		}
+		// The inner literals of nested maps checked as a product are
+		// accounted for by the check of the outermost literal.
+		descend := product == nil || !product.nested
+
+		if ignored {
+			if inheritsIgnore(fileComments, stack) {
+				// The check of the enclosing literal determines
+				// whether the ignore comment is necessary.
+				return true, resultIgnoreComment
+			}
+			// Check the map literal, and the map literals nested in
+			// it that the ignore comment applies to, without
+			// reporting, to determine whether the ignore comment is
+			// necessary.
+			var wouldReport bool
+			dryRun := func(analysis.Diagnostic) { wouldReport = true }
+			exhaustive := isExhaustiveResult(checkMapLiteral(pass, cfg, file, lit, mapType, product, relatedComments, dryRun))
+			if descend {
+				for _, inner := range nestedCompositeLits(lit) {
+					innerType, ok := mapLiteralType(inner, pass.TypesInfo)
+					if !ok || len(inner.Elts) == 0 {
+						continue
+					}
+					var innerProduct *productKeys
+					if cfg.productKeys {
+						innerProduct = analyzeProductKeys(pass, cfg, inner, innerType)
+					}
+					result := checkMapLiteral(pass, cfg, file, inner, innerType, innerProduct, relatedComments, dryRun)
+					exhaustive = exhaustive && isExhaustiveResult(result)
+				}
+			}
+			if wouldReport || !exhaustive {
+				return true, resultIgnoreComment
+			}
+			pass.Report(makeUnnecessaryIgnoreDiagnostic(pass.Fset, lit, relatedComments))
+			return descend, resultUnnecessaryIgnore
+		}
+		return descend, checkMapLiteral(pass, cfg, file, lit, mapType, product, relatedComments, pass.Report)
+	}
+}
+
+// checkMapLiteral checks the map literal for exhaustiveness, reporting
+// diagnostics using report. If product is non-nil, the map literal's
+// keys are checked over the product of enum members. It returns the
+// result of the check.
+func checkMapLiteral(pass *analysis.Pass, cfg mapConfig, file *ast.File, lit *ast.CompositeLit, mapType *types.Map, product *productKeys, relatedComments []*ast.CommentGroup, report func(analysis.Diagnostic)) string {
+	if product != nil {
+		return checkProductKeys(lit, product, relatedComments, report)
+	}
+
+	es, ok := composingEnumTypes(pass, mapType.Key())
+	if !ok || len(es) == 0 {
+		return resultEnumTypes
+	}
+
+	var checkl checklist
+	checkl.ignoreConstant(cfg.ignoreConstant)
+	checkl.ignoreType(cfg.ignoreType)
+	checkl.requireDeprecated(cfg.requireDeprecated)
+
+	for _, e := range es {
+		checkl.add(e.typ, e.members, pass.Pkg == e.typ.Pkg())
+	}
+
+	analyzeMapLiteral(lit, pass.TypesInfo, checkl.found)
+	if cfg.matchValues {
+		analyzeValueExprs(mapLiteralKeys(lit), pass.TypesInfo, checkl.found)
+	}
+	if cfg.reportMemberValues {
+		reportValueExprs(pass, file, mapLiteralKeys(lit), es, report)
+	}
+	if len(checkl.remaining()) == 0 {
+		return resultEnumMembersAccounted
+	}
+	enumTypes := dedupEnumTypes(toEnumTypes(es))
+	d := makeMapDiagnostic(lit, enumTypes, checkl.remaining())
+	if fix, ok := makeMapKeysFix(pass, file, lit, mapType, groupify(checkl.remaining(), enumTypes), cfg.fixValue); ok {
+		d.SuggestedFixes = []analysis.SuggestedFix{fix}
+	}
+	report(d)
+	return resultReportedDiagnostic
+}
+
+// compositeLitComments returns the comments associated with the composite
+// literal at the top of stack, and with the enclosing nodes that are
+// considered part of the composite literal's statement or declaration.
+func compositeLitComments(fileComments ast.CommentMap, stack []ast.Node) []*ast.CommentGroup {
+	var relatedComments []*ast.CommentGroup
+	for i := range stack {
+		// iterate over stack in the reverse order (from inner
+		// node to outer node)
+		node := stack[len(stack)-1-i]
+		switch node.(type) {
+		// need to check comments associated with following nodes,
+		// because logic of ast package doesn't associate comment
+		// with *ast.CompositeLit as required.
+		case *ast.CompositeLit, // stack[len(stack)-1]
+			*ast.ReturnStmt, // return ...
+			*ast.IndexExpr,  // map[enum]...{...}[key]
+			*ast.CallExpr,   // myfunc(map...)
+			*ast.UnaryExpr,  // &map...
+			*ast.AssignStmt, // variable assignment (without var keyword)
+			*ast.DeclStmt,   // var declaration, parent of *ast.GenDecl
+			*ast.GenDecl,    // var declaration, parent of *ast.ValueSpec
+			*ast.ValueSpec:  // var declaration
+			relatedComments = append(relatedComments, fileComments[node]...)
+			continue
+		default:
+			// stop iteration on the first inappropriate node
+			break
 		}
+	}
+	return relatedComments
+}
 
-		var checkl checklist
-		checkl.ignoreConstant(cfg.ignoreConstant)
-		checkl.ignoreType(cfg.ignoreType)
+// mapLiteralType returns the map type of the composite literal, if it is
+// a map literal whose type is written out.
+func mapLiteralType(lit *ast.CompositeLit, info *types.Info) (*types.Map, bool) {
+	t := info.Types[lit.Type].Type
+	if t == nil {
+		return nil, false
+	}
+	switch t := t.(type) {
+	case *types.Map:
+		return t, true
+	case *types.Named:
+		m, ok := t.Underlying().(*types.Map)
+		return m, ok
+	}
+	return nil, false
+}
 
-		for _, e := range es {
-			checkl.add(e.typ, e.members, pass.Pkg == e.typ.Pkg())
+// inheritsIgnore reports whether the ignore directive associated with the
+// composite literal at the top of stack is also associated with an
+// enclosing composite literal. The check of the outermost such literal
+// accounts for the nested literals when determining whether the directive
+// is necessary.
+func inheritsIgnore(fileComments ast.CommentMap, stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		if _, ok := stack[i].(*ast.CompositeLit); ok {
+			dirs, _ := parseDirectives(compositeLitComments(fileComments, stack[:i+1]))
+			return dirs.has(ignoreDirective)
 		}
+	}
+	return false
+}
+
+// nestedCompositeLits returns the composite literals nested in lit.
+func nestedCompositeLits(lit *ast.CompositeLit) []*ast.CompositeLit {
+	var out []*ast.CompositeLit
+	for _, e := range lit.Elts {
+		ast.Inspect(e, func(n ast.Node) bool {
+			if inner, ok := n.(*ast.CompositeLit); ok {
+				out = append(out, inner)
+			}
+			return true
+		})
+	}
+	return out
+}
 
-		analyzeMapLiteral(lit, pass.TypesInfo, checkl.found)
-		if len(checkl.remaining()) == 0 {
-			return true, resultEnumMembersAccounted
+// mapLiteralKeys returns the keys of the elements of the map literal.
+func mapLiteralKeys(lit *ast.CompositeLit) []ast.Expr {
+	var keys []ast.Expr
+	for _, e := range lit.Elts {
+		if kv, ok := e.(*ast.KeyValueExpr); ok {
+			keys = append(keys, kv.Key)
 		}
-		pass.Report(makeMapDiagnostic(lit, dedupEnumTypes(toEnumTypes(es)), checkl.remaining()))
-		return true, resultReportedDiagnostic
 	}
+	return keys
 }
 
 func analyzeMapLiteral(lit *ast.CompositeLit, info *types.Info, each func(constantValue)) {
@@ -127,13 +273,91 @@ func analyzeMapLiteral(lit *ast.CompositeLit, info *types.Info, each func(consta
 }
 
 func makeMapDiagnostic(lit *ast.CompositeLit, enumTypes []enumType, missing map[member]struct{}) analysis.Diagnostic {
+	names := diagnosticGroupNames(groupify(missing, enumTypes))
 	return analysis.Diagnostic{
-		Pos: lit.Pos(),
-		End: lit.End(),
+		Pos:      lit.Pos(),
+		End:      lit.End(),
+		Category: CategoryMissingKeys,
 		Message: fmt.Sprintf(
 			"missing keys in map of key type %s: %s",
 			diagnosticEnumTypes(enumTypes),
-			diagnosticGroups(groupify(missing, enumTypes)),
+			strings.Join(names, ", "),
 		),
+		Related: missingRelated(lit, names),
+	}
+}
+
+// makeMapKeysFix returns a suggested fix that adds an element to the map
+// literal for each of the groups of missing members. The value of each
+// element is determined by the fixValue kind. The ok return value is false
+// if a fix cannot be made; for example, if the file doesn't import a
+// member's package.
+func makeMapKeysFix(pass *analysis.Pass, file *ast.File, lit *ast.CompositeLit, mapType *types.Map, groups []group, fixValue string) (analysis.SuggestedFix, bool) {
+	last := lit.Elts[len(lit.Elts)-1]
+	multiline := !sameLine(pass.Fset, last.End(), lit.Rbrace)
+
+	var entries []string
+	for _, g := range groups {
+		// Only one of the same-valued members can be listed; listing
+		// more would be a duplicate key.
+		key, ok := memberExpr(file, pass.Pkg, g[0])
+		if !ok {
+			return analysis.SuggestedFix{}, false
+		}
+		if tp, ok := mapType.Key().(*types.TypeParam); ok {
+			key = tp.Obj().Name() + "(" + key + ")"
+		}
+		value, ok := mapFixValue(file, pass.Pkg, mapType.Elem(), g[0].name, fixValue)
+		if !ok {
+			return analysis.SuggestedFix{}, false
+		}
+		entries = append(entries, key+": "+value)
+	}
+
+	var edit analysis.TextEdit
+	if multiline {
+		// Insert one element per line before the closing brace. The
+		// last existing element is necessarily followed by a comma.
+		indent := indentAt(pass.Fset, last.Pos())
+		rbraceIndent := indentAt(pass.Fset, lit.Rbrace)
+		var buf strings.Builder
+		for _, e := range entries {
+			buf.WriteString(indent + e + ",\n")
+		}
+		edit = analysis.TextEdit{
+			Pos:     lit.Rbrace,
+			End:     lit.Rbrace,
+			NewText: []byte(strings.TrimPrefix(buf.String(), rbraceIndent) + rbraceIndent),
+		}
+	} else {
+		// Insert the elements inline after the last existing element.
+		edit = analysis.TextEdit{
+			Pos:     last.End(),
+			End:     last.End(),
+			NewText: []byte(", " + strings.Join(entries, ", ")),
+		}
+	}
+
+	return analysis.SuggestedFix{
+		Message:   "add missing keys",
+		TextEdits: []analysis.TextEdit{edit},
+	}, true
+}
+
+// mapFixValue returns the value expression for the map element with the
+// key named name. Values derived from the name are used only if the
+// element type's underlying type is string; otherwise the zero value is
+// used.
+func mapFixValue(file *ast.File, from *types.Package, elem types.Type, name string, fixValue string) (string, bool) {
+	if basic, ok := elem.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+		switch fixValue {
+		case mapValueName:
+			return strconv.Quote(name), true
+		case mapValueLower:
+			return strconv.Quote(strings.ToLower(name)), true
+		case mapValueSnake:
+			return strconv.Quote(snakeCase(name)), true
+		}
 	}
+	return zeroValue(file, from, elem)
 }
@@ -0,0 +1,235 @@
+package exhaustive
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// assignedMap is a map variable, declared with an enforce comment, whose
+// keys are checked for exhaustiveness across the index assignments to it.
+type assignedMap struct {
+	obj     *types.Var
+	mapType *types.Map
+	decl    ast.Node // node to report diagnostics at
+	body    ast.Node // function body to find assignments in; nil for package-level variables
+}
+
+// checkMapAssignments checks exhaustiveness of the keys assigned to map
+// variables that are populated by index assignments, such as
+//
+//	//exhaustive:enforce
+//	m := make(map[Kind]string)
+//	m[A] = "a"
+//	m[B] = "b"
+//
+// and reports diagnostics. Only map variables whose declaration is
+// associated with an enforce comment are checked, and only if their
+// initial value, if any, is a call to make or an empty map literal. For a
+// variable declared in a function, the assignments in the function body
+// are considered. For a package-level variable, the assignments in the
+// package's init functions are considered.
+func checkMapAssignments(pass *analysis.Pass, cfg mapConfig, generated boolCache, comments commentCache, inspect *inspector.Inspector) {
+	var maps []assignedMap
+
+	inspect.WithStack([]ast.Node{&ast.AssignStmt{}, &ast.ValueSpec{}}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		file := stack[0].(*ast.File)
+		if !cfg.checkGenerated && generated.get(file) {
+			return false
+		}
+
+		var names []*ast.Ident
+		var values []ast.Expr
+		var related []*ast.CommentGroup
+		fileComments := comments.get(pass.Fset, file)
+
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE || len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for _, e := range n.Lhs {
+				ident, ok := e.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				names = append(names, ident)
+			}
+			values = n.Rhs
+			related = fileComments[n]
+		case *ast.ValueSpec:
+			if len(n.Values) != 0 && len(n.Values) != len(n.Names) {
+				return true
+			}
+			names = n.Names
+			values = n.Values
+			// The comments for the spec, the enclosing declaration, and
+			// for a local declaration, the enclosing statement.
+			for i := len(stack) - 1; i >= 0; i-- {
+				switch stack[i].(type) {
+				case *ast.ValueSpec, *ast.GenDecl, *ast.DeclStmt:
+					related = append(related, fileComments[stack[i]]...)
+				}
+			}
+		}
+
+		body := enclosingFuncBody(stack)
+		var candidates []assignedMap
+		for i, name := range names {
+			obj, ok := pass.TypesInfo.Defs[name].(*types.Var)
+			if !ok {
+				continue
+			}
+			mapType, ok := obj.Type().Underlying().(*types.Map)
+			if !ok {
+				continue
+			}
+			if values != nil && !isEmptyMapInit(values[i], pass.TypesInfo) {
+				// Checked by the map literal checker, if a literal.
+				continue
+			}
+			candidates = append(candidates, assignedMap{obj, mapType, n, body})
+		}
+		if len(candidates) == 0 {
+			return true
+		}
+
+		directives, err := parseDirectives(related)
+		if err != nil {
+			pass.Report(makeInvalidDirectiveDiagnostic(n, err))
+			return true
+		}
+		if directives.has(enforceDirective) {
+			maps = append(maps, candidates...)
+		}
+		return true
+	})
+
+	if len(maps) == 0 {
+		return
+	}
+
+	var initBodies []ast.Node
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "init" && fn.Body != nil {
+				initBodies = append(initBodies, fn.Body)
+			}
+		}
+	}
+
+	for _, m := range maps {
+		es, ok := composingEnumTypes(pass, m.mapType.Key())
+		if !ok || len(es) == 0 {
+			continue
+		}
+
+		var checkl checklist
+		checkl.ignoreConstant(cfg.ignoreConstant)
+		checkl.ignoreType(cfg.ignoreType)
+		checkl.requireDeprecated(cfg.requireDeprecated)
+
+		for _, e := range es {
+			checkl.add(e.typ, e.members, pass.Pkg == e.typ.Pkg())
+		}
+
+		bodies := initBodies
+		if m.body != nil {
+			bodies = []ast.Node{m.body}
+		}
+		for _, body := range bodies {
+			analyzeMapAssignments(body, m.obj, pass.TypesInfo, checkl.found)
+		}
+
+		if len(checkl.remaining()) == 0 {
+			continue
+		}
+		enumTypes := dedupEnumTypes(toEnumTypes(es))
+		pass.Report(makeMapAssignmentsDiagnostic(m, enumTypes, checkl.remaining()))
+	}
+}
+
+// enclosingFuncBody returns the body of the innermost function in stack,
+// or nil if there is none.
+func enclosingFuncBody(stack []ast.Node) ast.Node {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			return fn.Body
+		case *ast.FuncLit:
+			return fn.Body
+		}
+	}
+	return nil
+}
+
+// isEmptyMapInit reports whether the expression is a call to the make
+// built-in or an empty composite literal.
+func isEmptyMapInit(e ast.Expr, info *types.Info) bool {
+	switch e := e.(type) {
+	case *ast.CallExpr:
+		ident, ok := e.Fun.(*ast.Ident)
+		if !ok {
+			return false
+		}
+		b, ok := info.Uses[ident].(*types.Builtin)
+		return ok && b.Name() == "make"
+	case *ast.CompositeLit:
+		return len(e.Elts) == 0
+	default:
+		return false
+	}
+}
+
+// analyzeMapAssignments calls each for the constant value of each key in
+// the index assignments to the map variable obj in the node.
+func analyzeMapAssignments(node ast.Node, obj *types.Var, info *types.Info, each func(constantValue)) {
+	visitLhs := func(e ast.Expr) {
+		index, ok := e.(*ast.IndexExpr)
+		if !ok {
+			return
+		}
+		ident, ok := index.X.(*ast.Ident)
+		if !ok || info.Uses[ident] != obj {
+			return
+		}
+		if val, ok := exprConstVal(index.Index, info); ok {
+			each(val)
+		}
+	}
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, e := range n.Lhs {
+				visitLhs(e)
+			}
+		case *ast.IncDecStmt:
+			visitLhs(n.X)
+		}
+		return true
+	})
+}
+
+func makeMapAssignmentsDiagnostic(m assignedMap, enumTypes []enumType, missing map[member]struct{}) analysis.Diagnostic {
+	names := diagnosticGroupNames(groupify(missing, enumTypes))
+	return analysis.Diagnostic{
+		Pos:      m.decl.Pos(),
+		End:      m.decl.End(),
+		Category: CategoryMissingKeys,
+		Message: fmt.Sprintf(
+			"missing keys assigned to map %s of key type %s: %s",
+			m.obj.Name(),
+			diagnosticEnumTypes(enumTypes),
+			strings.Join(names, ", "),
+		),
+		Related: missingRelated(m.decl, names),
+	}
+}
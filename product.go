@@ -0,0 +1,369 @@
+package exhaustive
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// productDimension is a component of a product key: either a field of a
+// struct key, or a level of a nested map.
+type productDimension struct {
+	typeName string                // for diagnostics
+	groups   []group               // same-valued members, in AST order
+	index    map[constantValue]int // member value -> index in groups
+}
+
+// productKeys is the result of analyzing a map literal whose keys are
+// checked for exhaustiveness over the product of enum members; see
+// analyzeProductKeys.
+type productKeys struct {
+	dims    []productDimension
+	nested  bool            // nested maps rather than a struct key
+	covered map[string]bool // see combinationKey
+}
+
+// analyzeProductKeys analyzes the map literal, of type mapType, if its
+// keys are product keys: either the key type is a struct type whose
+// fields are all of enum types, as in
+//
+//	map[struct{ From, To State }]Transition{
+//		{Idle, Running}: ...,
+//	}
+//
+// or the map is a chain of nested maps with enum key types, as in
+//
+//	map[State]map[Event]State{
+//		Idle: {Start: Running},
+//	}
+//
+// It returns nil if the keys aren't product keys, or if a key in the
+// literal can't be determined statically.
+func analyzeProductKeys(pass *analysis.Pass, cfg mapConfig, lit *ast.CompositeLit, mapType *types.Map) *productKeys {
+	newDim := func(t types.Type) (productDimension, bool) {
+		es, ok := composingEnumTypes(pass, t)
+		if !ok || len(es) == 0 {
+			return productDimension{}, false
+		}
+		var checkl checklist
+		checkl.ignoreConstant(cfg.ignoreConstant)
+		checkl.ignoreType(cfg.ignoreType)
+		checkl.requireDeprecated(cfg.requireDeprecated)
+		for _, e := range es {
+			checkl.add(e.typ, e.members, pass.Pkg == e.typ.Pkg())
+		}
+		enumTypes := dedupEnumTypes(toEnumTypes(es))
+		d := productDimension{
+			typeName: diagnosticEnumTypes(enumTypes),
+			groups:   groupify(checkl.remaining(), enumTypes),
+			index:    make(map[constantValue]int),
+		}
+		for i, g := range d.groups {
+			d.index[g[0].val] = i
+		}
+		return d, true
+	}
+
+	p := &productKeys{covered: make(map[string]bool)}
+
+	if _, ok := newDim(mapType.Key()); ok {
+		// A chain of nested maps.
+		for t := types.Type(mapType); ; {
+			m, ok := t.Underlying().(*types.Map)
+			if !ok {
+				break
+			}
+			d, ok := newDim(m.Key())
+			if !ok {
+				break
+			}
+			p.dims = append(p.dims, d)
+			t = m.Elem()
+		}
+		if len(p.dims) < 2 {
+			return nil
+		}
+		p.nested = true
+		if !p.analyzeNested(lit, nil, pass.TypesInfo) {
+			return nil
+		}
+		return p
+	}
+
+	st, ok := mapType.Key().Underlying().(*types.Struct)
+	if !ok || st.NumFields() == 0 {
+		return nil
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if !f.Exported() && f.Pkg() != pass.Pkg {
+			// Can't be set in a key literal in this package.
+			return nil
+		}
+		d, ok := newDim(f.Type())
+		if !ok {
+			return nil
+		}
+		p.dims = append(p.dims, d)
+	}
+	for _, e := range lit.Elts {
+		kv, ok := e.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := structKeyValues(kv.Key, st, pass.TypesInfo)
+		if !ok {
+			return nil
+		}
+		p.cover(key)
+	}
+	return p
+}
+
+// analyzeNested adds the combinations covered by the nested map literal
+// at the level after the key values in prefix. It returns false if a key
+// or value in the literal can't be determined statically.
+func (p *productKeys) analyzeNested(lit *ast.CompositeLit, prefix []constantValue, info *types.Info) bool {
+	for _, e := range lit.Elts {
+		kv, ok := e.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		val, ok := exprConstVal(kv.Key, info)
+		if !ok {
+			return false
+		}
+		key := append(prefix[:len(prefix):len(prefix)], val)
+		if len(key) == len(p.dims) {
+			p.cover(key)
+			continue
+		}
+		inner, ok := astutil.Unparen(kv.Value).(*ast.CompositeLit)
+		if !ok {
+			return false
+		}
+		if !p.analyzeNested(inner, key, info) {
+			return false
+		}
+	}
+	return true
+}
+
+// structKeyValues returns the values of the fields, in order, of the
+// struct key expression e, of struct type st. Each field must be
+// specified by a constant identifier.
+func structKeyValues(e ast.Expr, st *types.Struct, info *types.Info) ([]constantValue, bool) {
+	lit, ok := astutil.Unparen(e).(*ast.CompositeLit)
+	if !ok || len(lit.Elts) != st.NumFields() {
+		return nil, false
+	}
+	values := make([]constantValue, st.NumFields())
+	for i, elt := range lit.Elts {
+		field := i
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			ident, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				return nil, false
+			}
+			field = -1
+			for j := 0; j < st.NumFields(); j++ {
+				if st.Field(j).Name() == ident.Name {
+					field = j
+				}
+			}
+			if field == -1 {
+				return nil, false
+			}
+			elt = kv.Value
+		}
+		val, ok := exprConstVal(elt, info)
+		if !ok {
+			return nil, false
+		}
+		values[field] = val
+	}
+	return values, true
+}
+
+// cover records the combination of key values as present in the literal.
+// Combinations that include a value that isn't a member of the
+// corresponding dimension are disregarded.
+func (p *productKeys) cover(values []constantValue) {
+	combination := make([]int, len(values))
+	for i, val := range values {
+		j, ok := p.dims[i].index[val]
+		if !ok {
+			return
+		}
+		combination[i] = j
+	}
+	p.covered[combinationKey(combination)] = true
+}
+
+// combinationKey returns a map key for the combination of group indices.
+func combinationKey(combination []int) string {
+	return fmt.Sprint(combination)
+}
+
+// wildcard is the index, in a combination pattern, that matches each
+// group of a dimension.
+const wildcard = -1
+
+// missing returns the combinations that are neither covered nor
+// excluded. A run of trailing dimensions in which every combination is
+// missing is returned as wildcards, so that the result is compact.
+func (p *productKeys) missing(excluded [][]int) [][]int {
+	for _, d := range p.dims {
+		if len(d.groups) == 0 {
+			return nil
+		}
+	}
+
+	accounted := func(combination []int) bool {
+		if p.covered[combinationKey(combination)] {
+			return true
+		}
+	outer:
+		for _, pattern := range excluded {
+			for i, j := range pattern {
+				if j != wildcard && j != combination[i] {
+					continue outer
+				}
+			}
+			return true
+		}
+		return false
+	}
+
+	var walk func(prefix []int) (result [][]int, all bool)
+	walk = func(prefix []int) ([][]int, bool) {
+		if len(prefix) == len(p.dims) {
+			if accounted(prefix) {
+				return nil, false
+			}
+			return [][]int{prefix}, true
+		}
+		var result [][]int
+		all := true
+		for i := range p.dims[len(prefix)].groups {
+			r, a := walk(append(prefix[:len(prefix):len(prefix)], i))
+			result = append(result, r...)
+			all = all && a
+		}
+		if all {
+			pattern := append([]int(nil), prefix...)
+			for len(pattern) < len(p.dims) {
+				pattern = append(pattern, wildcard)
+			}
+			return [][]int{pattern}, true
+		}
+		return result, false
+	}
+
+	result, _ := walk(nil)
+	return result
+}
+
+// reExclusion matches a parenthesized combination in an exclude
+// directive.
+var reExclusion = regexp.MustCompile(`\(([^()]*)\)`)
+
+// parseExclusions parses the arguments of an exclude directive, such as
+// "(Idle, Stop), (Running, *)", into combination patterns. A member may
+// be written with or without its package name; "*" matches each member.
+func (p *productKeys) parseExclusions(arg string) ([][]int, error) {
+	var patterns [][]int
+	if rest := reExclusion.ReplaceAllString(arg, ""); strings.Trim(rest, ", \t") != "" {
+		return nil, fmt.Errorf("malformed exclusion list %q", arg)
+	}
+	for _, m := range reExclusion.FindAllStringSubmatch(arg, -1) {
+		names := strings.Split(m[1], ",")
+		if len(names) != len(p.dims) {
+			return nil, fmt.Errorf("exclusion %s has %d elements, want %d", m[0], len(names), len(p.dims))
+		}
+		pattern := make([]int, len(names))
+		for i, name := range names {
+			name = strings.TrimSpace(name)
+			if name == "*" {
+				pattern[i] = wildcard
+				continue
+			}
+			j, ok := p.dims[i].lookup(name)
+			if !ok {
+				return nil, fmt.Errorf("exclusion %s: %s is not a member of %s", m[0], name, p.dims[i].typeName)
+			}
+			pattern[i] = j
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// lookup returns the index of the group that has the named member.
+func (d productDimension) lookup(name string) (int, bool) {
+	for i, g := range d.groups {
+		for _, m := range g {
+			if m.name == name || diagnosticMember(m) == name {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// checkProductKeys checks the map literal, whose keys were analyzed by
+// analyzeProductKeys, for exhaustiveness, reporting diagnostics using
+// report. It returns the result of the check.
+func checkProductKeys(lit *ast.CompositeLit, p *productKeys, relatedComments []*ast.CommentGroup, report func(analysis.Diagnostic)) string {
+	var excluded [][]int
+	for _, arg := range directiveArgs(relatedComments, excludeComment) {
+		patterns, err := p.parseExclusions(arg)
+		if err != nil {
+			report(makeInvalidDirectiveDiagnostic(lit, err))
+			continue
+		}
+		excluded = append(excluded, patterns...)
+	}
+
+	missing := p.missing(excluded)
+	if len(missing) == 0 {
+		return resultEnumMembersAccounted
+	}
+	report(makeProductKeysDiagnostic(lit, p, missing))
+	return resultReportedDiagnostic
+}
+
+func makeProductKeysDiagnostic(lit *ast.CompositeLit, p *productKeys, missing [][]int) analysis.Diagnostic {
+	typeNames := make([]string, len(p.dims))
+	for i, d := range p.dims {
+		typeNames[i] = d.typeName
+	}
+	combinations := make([]string, len(missing))
+	for i, pattern := range missing {
+		elems := make([]string, len(pattern))
+		for j, k := range pattern {
+			if k == wildcard {
+				elems[j] = "*"
+				continue
+			}
+			elems[j] = diagnosticGroups([]group{p.dims[j].groups[k]})
+		}
+		combinations[i] = "(" + strings.Join(elems, ", ") + ")"
+	}
+	return analysis.Diagnostic{
+		Pos:      lit.Pos(),
+		End:      lit.End(),
+		Category: CategoryMissingKeys,
+		Message: fmt.Sprintf(
+			"missing combinations in map of key type (%s): %s",
+			strings.Join(typeNames, ", "),
+			strings.Join(combinations, ", "),
+		),
+		Related: missingRelated(lit, combinations),
+	}
+}
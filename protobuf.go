@@ -0,0 +1,164 @@
+package exhaustive
+
+import (
+	"go/types"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// With the -protobuf flag, the analyzer recognizes the enum types and the
+// oneof interfaces generated by protoc-gen-go, such as
+//
+//	type Color int32
+//
+//	const (
+//		Color_COLOR_UNSPECIFIED Color = 0
+//		Color_RED               Color = 1
+//	)
+//
+//	var (
+//		Color_name  = map[int32]string{...}
+//		Color_value = map[string]int32{...}
+//	)
+//
+//	func (Color) Descriptor() protoreflect.EnumDescriptor { ... }
+//
+// and
+//
+//	type isEvent_Payload interface {
+//		isEvent_Payload()
+//	}
+//
+//	type Event_Click struct {
+//		Click int32 `protobuf:"varint,1,opt,name=click,proto3,oneof"`
+//	}
+//
+//	func (*Event_Click) isEvent_Payload() {}
+
+// protoreflectPath is the import path of the protoreflect package.
+const protoreflectPath = "google.golang.org/protobuf/reflect/protoreflect"
+
+// protoUnspecifiedSuffix is the suffix of the name of the zero member of
+// a protobuf enum that is optional.
+const protoUnspecifiedSuffix = "_UNSPECIFIED"
+
+// isProtoEnum reports whether the enum type was generated by
+// protoc-gen-go: its package declares the T_name and T_value maps for the
+// type T, and T implements the methods of protoreflect.Enum.
+func isProtoEnum(et enumType) bool {
+	scope := et.Pkg().Scope()
+	isMap := func(name string, key, elem types.BasicKind) bool {
+		v, ok := scope.Lookup(name).(*types.Var)
+		if !ok {
+			return false
+		}
+		m, ok := v.Type().(*types.Map)
+		return ok && isBasic(m.Key(), key) && isBasic(m.Elem(), elem)
+	}
+	if !isMap(et.Name()+"_name", types.Int32, types.String) || !isMap(et.Name()+"_value", types.String, types.Int32) {
+		return false
+	}
+
+	mset := types.NewMethodSet(et.Type())
+	for name, result := range map[string]string{
+		"Descriptor": "EnumDescriptor",
+		"Type":       "EnumType",
+		"Number":     "EnumNumber",
+	} {
+		sel := mset.Lookup(et.Pkg(), name)
+		if sel == nil {
+			return false
+		}
+		sig := sel.Type().(*types.Signature)
+		if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+			return false
+		}
+		named, ok := sig.Results().At(0).Type().(*types.Named)
+		if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != protoreflectPath || named.Obj().Name() != result {
+			return false
+		}
+	}
+	return true
+}
+
+func isBasic(t types.Type, kind types.BasicKind) bool {
+	b, ok := t.(*types.Basic)
+	return ok && b.Kind() == kind
+}
+
+// protoReservedRe matches the name, without the T_ prefix of the Go type,
+// of a member of a protobuf enum that is a placeholder for a reserved
+// value: RESERVED, optionally prefixed by the enum's value prefix and
+// suffixed by a number, such as COLOR_RESERVED_3. Names such as
+// STATUS_RESERVED_FOR_ADMIN are of real members.
+var protoReservedRe = regexp.MustCompile(`^(?:[A-Z0-9]+_)*RESERVED(?:_[0-9]+)?$`)
+
+// markProtoOptional records, in em.Optional, the members of a protobuf
+// enum, of the named type, that need not be listed to satisfy
+// exhaustiveness: the zero member whose name ends in "_UNSPECIFIED", and
+// the members whose names match protoReservedRe, such as
+// Color_COLOR_RESERVED_3, which are placeholders for reserved values.
+// Values reserved with a reserved statement in the .proto file have no
+// members, so they never have to be listed.
+//
+// Deprecated values of a protobuf enum are documented as deprecated by
+// protoc-gen-go, so they are handled as other deprecated members are; see
+// markDeprecated.
+func (em *enumMembers) markProtoOptional(typeName string) {
+	mark := func(name string) {
+		if em.Optional == nil {
+			em.Optional = make(map[string]bool)
+		}
+		em.Optional[name] = true
+	}
+	for _, name := range em.ValueToNames["0"] {
+		if strings.HasSuffix(name, protoUnspecifiedSuffix) {
+			mark(name)
+		}
+	}
+	for _, name := range em.Names {
+		if protoReservedRe.MatchString(strings.TrimPrefix(name, typeName+"_")) {
+			mark(name)
+		}
+	}
+}
+
+// isOneofInterface reports whether the sealed interface, whose
+// implementing types are sm, is the interface of the wrapper types of a
+// protobuf oneof field. Such an interface is named isM_F, after its only
+// method, and each of its implementing types is a struct type with a
+// single field that is tagged as a member of a oneof.
+func isOneofInterface(intf *types.TypeName, sm sealedMembers) bool {
+	if !strings.HasPrefix(intf.Name(), "is") {
+		return false
+	}
+	iface, ok := intf.Type().Underlying().(*types.Interface)
+	if !ok || iface.NumMethods() != 1 || iface.Method(0).Name() != intf.Name() {
+		return false
+	}
+	for _, name := range sm.Names {
+		tn, ok := intf.Pkg().Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return false
+		}
+		st, ok := tn.Type().Underlying().(*types.Struct)
+		if !ok || st.NumFields() != 1 {
+			return false
+		}
+		tag, ok := reflect.StructTag(st.Tag(0)).Lookup("protobuf")
+		if !ok || !hasElement(strings.Split(tag, ","), "oneof") {
+			return false
+		}
+	}
+	return true
+}
+
+func hasElement(s []string, e string) bool {
+	for _, v := range s {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package exhaustive
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// markSentinels records, in em.Sentinels, the members that are sentinels,
+// such as the numKinds member in
+//
+//	const (
+//		KindA Kind = iota
+//		KindB
+//		numKinds
+//	)
+//
+// A sentinel is a member, among the trailing members whose names match
+// the pattern, whose value is the number of preceding members, the
+// largest value of the preceding members, or one more than that value.
+// Sentinels need not be listed to satisfy exhaustiveness. The pattern can
+// be nil, in which case there are no sentinels.
+func (em *enumMembers) markSentinels(pattern *regexp.Regexp) {
+	if pattern == nil {
+		return
+	}
+
+	start := len(em.Names)
+	for start > 0 && pattern.MatchString(em.Names[start-1]) {
+		start--
+	}
+	if start == 0 || start == len(em.Names) {
+		// Either every member matches, in which case the members are
+		// unlikely to be sentinels, or none does.
+		return
+	}
+
+	var max int64
+	for i, name := range em.Names[:start] {
+		v, ok := intValue(em.NameToValue[name])
+		if !ok {
+			return
+		}
+		if i == 0 || v > max {
+			max = v
+		}
+	}
+	for _, name := range em.Names[start:] {
+		v, ok := intValue(em.NameToValue[name])
+		if !ok || (v != int64(start) && v != max && v != max+1) {
+			continue
+		}
+		if em.Sentinels == nil {
+			em.Sentinels = make(map[string]bool)
+		}
+		em.Sentinels[name] = true
+	}
+}
+
+// intValue returns the value of an integer constant that fits in 64 bits.
+func intValue(val constantValue) (int64, bool) {
+	v, err := strconv.ParseInt(string(val), 10, 64)
+	return v, err == nil
+}
@@ -1,10 +1,14 @@
 package exhaustive
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
 	"regexp"
+	"strings"
+	"text/template"
 
 	"golang.org/x/tools/go/analysis"
 )
@@ -33,11 +37,25 @@ const (
 	resultKeyNilPkg       = "nil map key package"
 	resultKeyNotEnum      = "not all map key type terms are known enum types"
 
+	resultEmptyArrayLiteral = "empty array or slice literal"
+	resultNotArrayLiteral   = "not array or slice literal"
+	resultIndexNotEnum      = "array or slice literal not indexed by a named type"
+
 	resultNoSwitchTag = "no switch tag"
 	resultTagNotValue = "switch tag not value type"
 	resultTagNilPkg   = "nil switch tag package"
 	resultTagNotEnum  = "not all switch tag terms are known enum types"
 
+	resultTagNotSealed = "type switch operand not a sealed interface"
+	resultTagNotOneof  = "type switch operand not a protobuf oneof interface"
+
+	resultUnknownGroup = "enforce directive names an unknown group"
+
+	resultElseIf     = "else if of if-else chain"
+	resultNotIfChain = "not if-else chain of enum comparisons"
+
+	resultNotFlagEnum = "bit test of enum type that is not a bit-flag enum"
+
 	resultNotPush              = "not push"
 	resultGeneratedFile        = "generated file"
 	resultIgnoreComment        = "has ignore comment"
@@ -47,16 +65,31 @@ const (
 	resultMissingDefaultCase   = "missing required default case"
 	resultReportedDiagnostic   = "reported diagnostic"
 	resultEnumTypes            = "invalid or empty composing enum types"
+	resultUnnecessaryIgnore    = "has unnecessary ignore comment"
 )
 
+// isExhaustiveResult reports whether the result, returned by a node
+// visitor for a checked node, indicates that the node is exhaustive.
+func isExhaustiveResult(result string) bool {
+	return result == resultEnumMembersAccounted || result == resultDefaultCaseSuffices
+}
+
 // switchConfig is configuration for switchChecker.
 type switchConfig struct {
 	explicit                   bool
 	defaultSignifiesExhaustive bool
 	defaultCaseRequired        bool
 	checkGenerated             bool
-	ignoreConstant             *regexp.Regexp // can be nil
-	ignoreType                 *regexp.Regexp // can be nil
+	ignoreConstant             *regexp.Regexp     // can be nil
+	ignoreType                 *regexp.Regexp     // can be nil
+	caseBody                   *template.Template // can be nil
+	defaultBody                *template.Template // can be nil
+	reportUnnecessaryIgnore    bool
+	matchValues                bool
+	reportMemberValues         bool
+	requireDeprecated          bool
+	reportDeprecated           bool
+	buildConstraints           bool
 }
 
 // switchChecker returns a node visitor that checks exhaustiveness of
@@ -83,11 +116,15 @@ func switchChecker(pass *analysis.Pass, cfg switchConfig, generated boolCache, c
 
 		switchComments := comments.get(pass.Fset, file)[sw]
 		uDirectives, err := parseDirectives(switchComments)
+		if err == nil {
+			err = uDirectives.validateUse("a switch statement", 0)
+		}
 		if err != nil {
 			pass.Report(makeInvalidDirectiveDiagnostic(sw, err))
 		}
 
-		if !cfg.explicit && uDirectives.has(ignoreDirective) {
+		ignored := !cfg.explicit && uDirectives.has(ignoreDirective)
+		if ignored && !cfg.reportUnnecessaryIgnore {
 			// Skip checking of this switch statement due to ignore
 			// comment. Still return true because there may be nested
 			// switch statements that are not to be ignored.
@@ -108,52 +145,141 @@ func switchChecker(pass *analysis.Pass, cfg switchConfig, generated boolCache, c
 			requireDefaultCase = true
 		}
 
-		if sw.Tag == nil {
-			return true, resultNoSwitchTag
-		}
+		group, _ := switchGroup(switchComments)
 
-		t := pass.TypesInfo.Types[sw.Tag]
-		if !t.IsValue() {
-			return true, resultTagNotValue
+		if ignored {
+			// Check the switch statement, without reporting, to
+			// determine whether the ignore comment is necessary.
+			var wouldReport bool
+			result := checkSwitch(pass, cfg, file, sw, requireDefaultCase, group, func(analysis.Diagnostic) { wouldReport = true })
+			if wouldReport || !isExhaustiveResult(result) {
+				return true, resultIgnoreComment
+			}
+			pass.Report(makeUnnecessaryIgnoreDiagnostic(pass.Fset, sw, switchComments))
+			return true, resultUnnecessaryIgnore
 		}
+		return true, checkSwitch(pass, cfg, file, sw, requireDefaultCase, group, pass.Report)
+	}
+}
 
-		es, ok := composingEnumTypes(pass, t.Type)
-		if !ok || len(es) == 0 {
-			return true, resultEnumTypes
+// checkSwitch checks the exhaustiveness of the switch statement, and
+// reports diagnostics using the report function. If group is non-empty,
+// the switch statement has to list exactly the members of the named
+// group. It returns a short description of the result; see nodeVisitor.
+func checkSwitch(pass *analysis.Pass, cfg switchConfig, file *ast.File, sw *ast.SwitchStmt, requireDefaultCase bool, group string, report func(analysis.Diagnostic)) string {
+	// tag is the expression that the switch statement compares against
+	// enum members. For a tagless switch statement, it is the operand that
+	// the case expressions compare against enum members.
+	tag := sw.Tag
+	if tag == nil {
+		operand, ok := taglessSwitchOperand(sw, pass.TypesInfo, true)
+		if !ok {
+			return resultNoSwitchTag
 		}
+		tag = operand
+	}
 
-		var checkl checklist
-		checkl.ignoreConstant(cfg.ignoreConstant)
-		checkl.ignoreType(cfg.ignoreType)
+	t := pass.TypesInfo.Types[tag]
+	if !t.IsValue() {
+		return resultTagNotValue
+	}
 
-		for _, e := range es {
-			checkl.add(e.typ, e.members, pass.Pkg == e.typ.Pkg())
+	es, ok := composingEnumTypes(pass, t.Type)
+	if !ok || len(es) == 0 {
+		es = varEnumsReferenced(pass, switchCaseValues(sw, pass.TypesInfo))
+		if len(es) == 0 {
+			return resultEnumTypes
 		}
+	}
+	vars := hasVarEnum(es)
+	if sw.Tag == nil && !vars {
+		if _, ok := taglessSwitchOperand(sw, pass.TypesInfo, false); !ok {
+			// A comparison against a variable, for an enum that
+			// isn't a variable enum.
+			return resultNoSwitchTag
+		}
+	}
 
-		defaultCaseExists := analyzeSwitchClauses(sw, pass.TypesInfo, checkl.found)
-		if !defaultCaseExists && requireDefaultCase {
-			// Even if the switch explicitly enumerates all the
-			// enum values, the user has still required all switches
-			// to have a default case. We check this first to avoid
-			// early-outs
-			pass.Report(makeMissingDefaultDiagnostic(sw, dedupEnumTypes(toEnumTypes(es))))
+	flags := allFlagEnums(es)
+	if sw.Tag == nil && !flags && hasFlagsComparison(switchCaseExprs(sw), pass.TypesInfo, vars) {
+		return resultNotFlagEnum
+	}
+
+	var checkl checklist
+	checkl.ignoreConstant(cfg.ignoreConstant)
+	checkl.ignoreType(cfg.ignoreType)
+	checkl.requireDeprecated(cfg.requireDeprecated)
+
+	for _, e := range es {
+		checkl.add(e.typ, e.members, pass.Pkg == e.typ.Pkg())
+	}
+	if mask, ok := flagsMask(tag, pass.TypesInfo); ok {
+		// Bits outside the mask can't be set in the tag.
+		checkl.restrictFlags(mask)
+	}
+	if group != "" {
+		if !hasGroup(es, group) {
+			report(makeInvalidDirectiveDiagnostic(sw, fmt.Errorf("unknown group %q of enum type %s", group, diagnosticEnumTypes(dedupEnumTypes(toEnumTypes(es))))))
+			return resultUnknownGroup
+		}
+		checkl.restrictGroup(group)
+		reportOutsideGroup(sw, pass.TypesInfo, es, group, report)
+	}
+	if cfg.buildConstraints {
+		checkl.restrictConstraint(fileConstraint(pass.Fset, file))
+	}
 
-			return true, resultMissingDefaultCase
+	var defaultCaseExists bool
+	if sw.Tag == nil {
+		defaultCaseExists = analyzeTaglessSwitchClauses(sw, pass.TypesInfo, vars, checkl.found)
+	} else {
+		defaultCaseExists = analyzeSwitchClauses(sw, pass.TypesInfo, vars, checkl.found)
+		if cfg.matchValues {
+			analyzeValueExprs(switchCaseExprs(sw), pass.TypesInfo, checkl.found)
+		}
+		if cfg.reportMemberValues {
+			reportValueExprs(pass, file, switchCaseExprs(sw), es, report)
 		}
-		if len(checkl.remaining()) == 0 {
-			// All enum members accounted for.
-			// Nothing to report.
-			return true, resultEnumMembersAccounted
+		if cfg.reportDeprecated {
+			reportDeprecated(pass, file, switchCaseExprs(sw), es, report)
 		}
-		if defaultCaseExists && cfg.defaultSignifiesExhaustive {
-			// Though enum members are not accounted for, the
-			// existence of the default case signifies
-			// exhaustiveness.  So don't report.
-			return true, resultDefaultCaseSuffices
+	}
+	if !defaultCaseExists && requireDefaultCase {
+		// Even if the switch explicitly enumerates all the
+		// enum values, the user has still required all switches
+		// to have a default case. We check this first to avoid
+		// early-outs
+		enumTypes := dedupEnumTypes(toEnumTypes(es))
+		d := makeMissingDefaultDiagnostic(sw, enumTypes)
+		fix, err := makeDefaultCaseFix(pass, file, sw, tag, enumTypes, cfg.defaultBody)
+		if err == nil {
+			d.SuggestedFixes = []analysis.SuggestedFix{fix}
 		}
-		pass.Report(makeSwitchDiagnostic(sw, dedupEnumTypes(toEnumTypes(es)), checkl.remaining()))
-		return true, resultReportedDiagnostic
+		report(d)
+		reportFixError(sw, err, report)
+
+		return resultMissingDefaultCase
+	}
+	if len(checkl.remaining()) == 0 {
+		// All enum members accounted for.
+		// Nothing to report.
+		return resultEnumMembersAccounted
 	}
+	if defaultCaseExists && cfg.defaultSignifiesExhaustive {
+		// Though enum members are not accounted for, the
+		// existence of the default case signifies
+		// exhaustiveness.  So don't report.
+		return resultDefaultCaseSuffices
+	}
+	enumTypes := dedupEnumTypes(toEnumTypes(es))
+	d := makeSwitchDiagnostic(sw, enumTypes, checkl.remaining())
+	fix, err := makeSwitchCasesFix(pass, file, sw, tag, t.Type, enumTypes, groupify(checkl.remaining(), enumTypes), flags, cfg.caseBody)
+	if err == nil {
+		d.SuggestedFixes = []analysis.SuggestedFix{fix}
+	}
+	report(d)
+	reportFixError(sw, err, report)
+	return resultReportedDiagnostic
 }
 
 func isDefaultCase(c *ast.CaseClause) bool {
@@ -161,11 +287,11 @@ func isDefaultCase(c *ast.CaseClause) bool {
 }
 
 // analyzeSwitchClauses analyzes the clauses in the supplied switch
-// statement. The info param typically is pass.TypesInfo. The each
-// function is called for each enum member name found in the switch
-// statement. The hasDefaultCase return value indicates whether the
-// switch statement has a default clause.
-func analyzeSwitchClauses(sw *ast.SwitchStmt, info *types.Info, each func(val constantValue)) (hasDefaultCase bool) {
+// statement. The info param typically is pass.TypesInfo. The vars param
+// is passed to exprMemberVal. The each function is called for each enum
+// member name found in the switch statement. The hasDefaultCase return
+// value indicates whether the switch statement has a default clause.
+func analyzeSwitchClauses(sw *ast.SwitchStmt, info *types.Info, vars bool, each func(val constantValue)) (hasDefaultCase bool) {
 	for _, stmt := range sw.Body.List {
 		caseCl := stmt.(*ast.CaseClause)
 		if isDefaultCase(caseCl) {
@@ -173,7 +299,7 @@ func analyzeSwitchClauses(sw *ast.SwitchStmt, info *types.Info, each func(val co
 			continue
 		}
 		for _, expr := range caseCl.List {
-			if val, ok := exprConstVal(expr, info); ok {
+			if val, ok := exprMemberVal(expr, info, vars); ok {
 				each(val)
 			}
 		}
@@ -181,22 +307,238 @@ func analyzeSwitchClauses(sw *ast.SwitchStmt, info *types.Info, each func(val co
 	return hasDefaultCase
 }
 
+// taglessSwitchOperand returns the operand that the case expressions of
+// the tagless switch statement compare against constants. Each case
+// expression must satisfy equalityOperand, with the same operand.
+func taglessSwitchOperand(sw *ast.SwitchStmt, info *types.Info, vars bool) (ast.Expr, bool) {
+	exprs := switchCaseExprs(sw)
+	if len(exprs) == 0 {
+		return nil, false
+	}
+	return equalityOperand(exprs, info, vars)
+}
+
+// switchCaseExprs returns the expressions in the case clauses of the
+// switch statement.
+func switchCaseExprs(sw *ast.SwitchStmt) []ast.Expr {
+	var exprs []ast.Expr
+	for _, stmt := range sw.Body.List {
+		exprs = append(exprs, stmt.(*ast.CaseClause).List...)
+	}
+	return exprs
+}
+
+// switchCaseValues returns the expressions that the switch statement
+// compares against: the case expressions or, for a tagless switch
+// statement, the constants or variables that they compare the operand
+// against.
+func switchCaseValues(sw *ast.SwitchStmt, info *types.Info) []ast.Expr {
+	exprs := switchCaseExprs(sw)
+	if sw.Tag == nil {
+		return comparedConstants(exprs, info, true)
+	}
+	return exprs
+}
+
+// analyzeTaglessSwitchClauses is like analyzeSwitchClauses, but for a
+// tagless switch statement whose case expressions satisfy
+// taglessSwitchOperand.
+func analyzeTaglessSwitchClauses(sw *ast.SwitchStmt, info *types.Info, vars bool, each func(val constantValue)) (hasDefaultCase bool) {
+	for _, stmt := range sw.Body.List {
+		caseCl := stmt.(*ast.CaseClause)
+		if isDefaultCase(caseCl) {
+			hasDefaultCase = true
+			continue
+		}
+		analyzeEqualityComparisons(caseCl.List, info, vars, each)
+	}
+	return hasDefaultCase
+}
+
 func makeSwitchDiagnostic(sw *ast.SwitchStmt, enumTypes []enumType, missing map[member]struct{}) analysis.Diagnostic {
+	names := diagnosticGroupNames(groupify(missing, enumTypes))
 	return analysis.Diagnostic{
-		Pos: sw.Pos(),
-		End: sw.End(),
+		Pos:      sw.Pos(),
+		End:      sw.End(),
+		Category: CategoryMissingCases,
 		Message: fmt.Sprintf(
 			"missing cases in switch of type %s: %s",
 			diagnosticEnumTypes(enumTypes),
-			diagnosticGroups(groupify(missing, enumTypes)),
+			strings.Join(names, ", "),
 		),
+		Related: missingRelated(sw, names),
+	}
+}
+
+// makeSwitchCasesFix returns a suggested fix that adds a case clause for
+// each of the groups of missing members to the switch statement. The
+// body of each case clause is the expansion of the body template. The
+// error is non-nil if a fix cannot be made; see errNoFix and
+// unresolvedPackageError.
+func makeSwitchCasesFix(pass *analysis.Pass, file *ast.File, sw *ast.SwitchStmt, tagExpr ast.Expr, tagType types.Type, enumTypes []enumType, groups []group, flags bool, body *template.Template) (analysis.SuggestedFix, error) {
+	tag := newSwitchTag(pass, sw, tagExpr)
+
+	var buf strings.Builder
+	var allStmts []string
+	insertPos, prefix, indent := caseClauseInsertion(pass.Fset, sw, sw.Body)
+	buf.WriteString(prefix)
+
+	for _, g := range groups {
+		// Only one of the same-valued members can be listed; listing
+		// more would be a duplicate case.
+		expr, ok := memberExpr(file, pass.Pkg, g[0])
+		if !ok {
+			return analysis.SuggestedFix{}, errNoFix
+		}
+		if tp, ok := tagType.(*types.TypeParam); ok {
+			expr = tp.Obj().Name() + "(" + expr + ")"
+		}
+		if sw.Tag == nil {
+			if flags {
+				expr = tag.expr + "&" + expr + " != 0"
+			} else {
+				expr = tag.expr + " == " + expr
+			}
+		}
+		data := fixData{Type: diagnosticEnumTypes(enumTypes), Member: expr, tag: tag.expr}
+		stmts, err := executeFixTemplate(body, &data)
+		if err != nil {
+			return analysis.SuggestedFix{}, errNoFix
+		}
+		tag.used = tag.used || data.usedTag
+		allStmts = append(allStmts, stmts...)
+
+		buf.WriteString("case " + expr + ":\n")
+		writeStmts(&buf, stmts, indent+"\t")
+		buf.WriteString(indent)
+	}
+
+	edits, err := tag.edits()
+	if err != nil {
+		return analysis.SuggestedFix{}, err
+	}
+	imports, err := importEdits(pass, file, sw.Pos(), allStmts, tag.bind)
+	if err != nil {
+		return analysis.SuggestedFix{}, err
+	}
+	edits = append(edits, imports...)
+	return analysis.SuggestedFix{
+		Message: "add missing cases",
+		TextEdits: append(edits, analysis.TextEdit{
+			Pos:     insertPos,
+			End:     insertPos,
+			NewText: []byte(buf.String()),
+		}),
+	}, nil
+}
+
+// makeDefaultCaseFix returns a suggested fix that adds a default case
+// clause to the switch statement. The body of the clause is the expansion
+// of the body template. The error is non-nil if a fix cannot be made; see
+// errNoFix and unresolvedPackageError.
+func makeDefaultCaseFix(pass *analysis.Pass, file *ast.File, sw *ast.SwitchStmt, tagExpr ast.Expr, enumTypes []enumType, body *template.Template) (analysis.SuggestedFix, error) {
+	indent := indentAt(pass.Fset, sw.Pos())
+	if n := len(sw.Body.List); n != 0 {
+		indent = indentAt(pass.Fset, sw.Body.List[n-1].Pos())
+	}
+
+	tag := newSwitchTag(pass, sw, tagExpr)
+	data := fixData{Type: diagnosticEnumTypes(enumTypes), tag: tag.expr}
+	stmts, err := executeFixTemplate(body, &data)
+	if err != nil {
+		return analysis.SuggestedFix{}, errNoFix
+	}
+	tag.used = data.usedTag
+
+	var buf strings.Builder
+	if sameLine(pass.Fset, sw.Body.Lbrace, sw.Body.Rbrace) {
+		buf.WriteString("\n" + indent)
+	}
+	buf.WriteString("default:\n")
+	writeStmts(&buf, stmts, indent+"\t")
+	buf.WriteString(indent)
+
+	edits, err := tag.edits()
+	if err != nil {
+		return analysis.SuggestedFix{}, err
+	}
+	imports, err := importEdits(pass, file, sw.Pos(), stmts, tag.bind)
+	if err != nil {
+		return analysis.SuggestedFix{}, err
+	}
+	edits = append(edits, imports...)
+	return analysis.SuggestedFix{
+		Message: "add default case",
+		TextEdits: append(edits, analysis.TextEdit{
+			Pos:     sw.Body.Rbrace,
+			End:     sw.Body.Rbrace,
+			NewText: []byte(buf.String()),
+		}),
+	}, nil
+}
+
+// switchTag tracks how statements inserted by a suggested fix refer to
+// the tag of a switch statement. If evaluating the tag may have side
+// effects, the inserted statements refer to a new variable that the tag
+// is bound to instead. The operand of a tagless switch statement can't be
+// bound, so inserted statements can't refer to it if it has side effects.
+type switchTag struct {
+	sw     *ast.SwitchStmt
+	expr   string // expression that refers to the tag
+	bind   string // if non-empty, the name of the variable to bind the tag to
+	impure bool   // whether the tag is a tagless switch operand with side effects
+	used   bool   // whether inserted statements refer to the tag
+}
+
+// newSwitchTag returns a switchTag for the expression that the switch
+// statement compares against enum members: the switch tag or, for a
+// tagless switch statement, the operand of the case expressions.
+func newSwitchTag(pass *analysis.Pass, sw *ast.SwitchStmt, tag ast.Expr) *switchTag {
+	if tag == sw.Tag && hasSideEffects(tag, pass.TypesInfo) {
+		name := unusedName(pass.Pkg, sw.Body.Lbrace, "v")
+		return &switchTag{sw: sw, expr: name, bind: name}
+	}
+	return &switchTag{
+		sw:     sw,
+		expr:   nodeString(pass.Fset, tag),
+		impure: tag != sw.Tag && hasSideEffects(tag, pass.TypesInfo),
 	}
 }
 
+// edits returns the edits, if any, needed to bind the tag to a variable.
+// The error is non-nil if inserted statements refer to the tag but can't
+// without evaluating it again: either the tag needs to be bound and the
+// switch statement's init statement is already in use (errNoFix), or the
+// tag is the operand of a tagless switch statement (tagSideEffectsError).
+func (t *switchTag) edits() (edits []analysis.TextEdit, err error) {
+	if !t.used {
+		return nil, nil
+	}
+	if t.impure {
+		return nil, &tagSideEffectsError{expr: t.expr}
+	}
+	if t.bind == "" {
+		return nil, nil
+	}
+	if t.sw.Init != nil {
+		return nil, errNoFix
+	}
+	return []analysis.TextEdit{{
+		Pos:     t.sw.Tag.Pos(),
+		End:     t.sw.Tag.Pos(),
+		NewText: []byte(t.bind + " := "),
+	}, {
+		Pos:     t.sw.Tag.End(),
+		End:     t.sw.Tag.End(),
+		NewText: []byte("; " + t.bind),
+	}}, nil
+}
+
 func makeMissingDefaultDiagnostic(sw *ast.SwitchStmt, enumTypes []enumType) analysis.Diagnostic {
 	return analysis.Diagnostic{
-		Pos: sw.Pos(),
-		End: sw.End(),
+		Pos:      sw.Pos(),
+		End:      sw.End(),
+		Category: CategoryMissingDefault,
 		Message: fmt.Sprintf(
 			"missing default case in switch of type %s",
 			diagnosticEnumTypes(enumTypes),
@@ -204,13 +546,44 @@ func makeMissingDefaultDiagnostic(sw *ast.SwitchStmt, enumTypes []enumType) anal
 	}
 }
 
-func makeInvalidDirectiveDiagnostic(node ast.Node, err error) analysis.Diagnostic {
+// makeUnnecessaryIgnoreDiagnostic returns a diagnostic, with a suggested
+// fix that removes the comment, for an ignore directive comment that
+// is unnecessary. The comments are the comments associated with node.
+func makeUnnecessaryIgnoreDiagnostic(fset *token.FileSet, node ast.Node, comments []*ast.CommentGroup) analysis.Diagnostic {
+	c := findDirectiveComment(comments, ignoreComment)
 	return analysis.Diagnostic{
-		Pos: node.Pos(),
-		End: node.End(),
+		Pos:      c.Pos(),
+		End:      c.End(),
+		Category: CategoryUnnecessaryIgnore,
+		Message:  "unnecessary " + exhaustiveComment + ignoreComment + " directive",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "remove " + exhaustiveComment + ignoreComment + " directive",
+			TextEdits: []analysis.TextEdit{removeCommentEdit(fset, c, node)},
+		}},
+	}
+}
+
+func makeInvalidDirectiveDiagnostic(node ast.Node, err error) analysis.Diagnostic {
+	d := analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: CategoryInvalidDirective,
 		Message: fmt.Sprintf(
 			"failed to parse directives: %s",
 			err,
 		),
 	}
+	var dirErr *directiveError
+	if errors.As(err, &dirErr) && dirErr.suggestion != "" {
+		d.Message += fmt.Sprintf(" (did you mean %q?)", dirErr.suggestion)
+		d.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: fmt.Sprintf("change to %q", dirErr.suggestion),
+			TextEdits: []analysis.TextEdit{{
+				Pos:     dirErr.comment.Pos(),
+				End:     dirErr.comment.End(),
+				NewText: []byte(dirErr.suggestion),
+			}},
+		}}
+	}
+	return d
 }
@@ -32,9 +32,14 @@ func TestMakeSwitchDiagnostic(t *testing.T) {
 
 	got := makeSwitchDiagnostic(sw, []enumType{et}, missing)
 	want := analysis.Diagnostic{
-		Pos:     1,
-		End:     11,
-		Message: "missing cases in switch of type enumpkg.Biome: enumpkg.Savanna, enumpkg.Desert",
+		Pos:      1,
+		End:      11,
+		Category: CategoryMissingCases,
+		Message:  "missing cases in switch of type enumpkg.Biome: enumpkg.Savanna, enumpkg.Desert",
+		Related: []analysis.RelatedInformation{
+			{Pos: 1, End: 11, Message: "enumpkg.Savanna"},
+			{Pos: 1, End: 11, Message: "enumpkg.Desert"},
+		},
 	}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("got %+v, want %+v", got, want)
@@ -70,7 +75,7 @@ func TestAnalyzeSwitchClauses(t *testing.T) {
 		t.Helper()
 
 		var got []constantValue
-		gotDefaultExists := analyzeSwitchClauses(sw, info, func(val constantValue) {
+		gotDefaultExists := analyzeSwitchClauses(sw, info, false, func(val constantValue) {
 			got = append(got, val)
 		})
 
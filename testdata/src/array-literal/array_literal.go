@@ -0,0 +1,62 @@
+package arrayliteral
+
+type Kind int // want Kind:"^KindA,KindB,KindC,kindCount$"
+
+const (
+	KindA Kind = iota
+	KindB
+	KindC
+	kindCount
+)
+
+type Handler func()
+
+var _ = [...]string{ // want "^missing keys in array of index type arrayliteral.Kind: arrayliteral.KindC, arrayliteral.kindCount$"
+	KindA: "a",
+	KindB: "b",
+}
+
+var _ = [...]string{
+	KindA:     "a",
+	KindB:     "b",
+	KindC:     "c",
+	kindCount: "",
+}
+
+// The length of the array excludes kindCount.
+var _ = [kindCount]string{
+	KindA: "a",
+	KindB: "b",
+	KindC: "c",
+}
+
+var _ = []Handler{KindA: nil, KindC: nil} // want "^missing keys in slice of index type arrayliteral.Kind: arrayliteral.KindB, arrayliteral.kindCount$"
+
+// Unkeyed elements following a keyed element continue from its index.
+var _ = [kindCount]string{KindA: "a", "b", "c"}
+
+var _ = [kindCount]string{ // want "^missing keys in array of index type arrayliteral.Kind: arrayliteral.KindB$"
+	KindC: "c",
+	KindA: "a",
+}
+
+var _ = [kindCount]string{"a", "b", "c"}
+
+var _ = [kindCount]string{"a", "b"} // want "^length of unkeyed array literal does not match index type arrayliteral.Kind: missing arrayliteral.KindC$"
+
+// Not enum-indexed.
+var _ = [...]string{"a", "b"}
+var _ = []string{0: "a", 2: "c"}
+var _ = [3]string{"a"}
+
+func _a() {
+	//exhaustive:ignore
+	_ = [kindCount]string{
+		KindA: "a",
+	}
+
+	_ = [][kindCount]int{
+		{KindA: 1, KindB: 2, KindC: 3},
+		{KindA: 1}, // want "^missing keys in array of index type arrayliteral.Kind: arrayliteral.KindB, arrayliteral.KindC$"
+	}
+}
@@ -0,0 +1,9 @@
+package downstream
+
+import buildconstraints "build-constraints"
+
+func _a(k buildconstraints.Kind) {
+	switch k {
+	case buildconstraints.KindA, buildconstraints.KindB:
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !exhaustive_never && !exhaustive_other
+
+package downstream
+
+import buildconstraints "build-constraints"
+
+func _b(k buildconstraints.Kind) {
+	switch k { // want "^missing cases in switch of type buildconstraints.Kind: buildconstraints.KindExtra$"
+	case buildconstraints.KindA, buildconstraints.KindB:
+	}
+}
@@ -0,0 +1,20 @@
+package buildconstraints
+
+type Kind int // want Kind:"^KindA,KindB,KindExtra,KindNever,KindNever2,KindNever3$"
+
+const (
+	KindA Kind = iota
+	KindB
+)
+
+func _a(k Kind) {
+	// KindExtra is declared in a file that isn't built in every
+	// configuration that this file is built in.
+	switch k {
+	case KindA, KindB:
+	}
+
+	switch k { // want "^missing cases in switch of type buildconstraints.Kind: buildconstraints.KindB$"
+	case KindA:
+	}
+}
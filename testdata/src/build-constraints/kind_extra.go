@@ -0,0 +1,11 @@
+//go:build !exhaustive_never
+
+package buildconstraints
+
+const KindExtra Kind = 10
+
+func _b(k Kind) {
+	switch k { // want "^missing cases in switch of type buildconstraints.Kind: buildconstraints.KindExtra$"
+	case KindA, KindB:
+	}
+}
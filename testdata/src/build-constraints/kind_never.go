@@ -0,0 +1,14 @@
+//go:build exhaustive_never
+
+package buildconstraints
+
+// The file is excluded from the build, but with -build-constraints its
+// members are members of Kind in every build configuration, required only
+// in files built exclusively with the exhaustive_never tag.
+const KindNever Kind = 20
+
+const (
+	KindNever2 Kind = 30 + iota
+	KindNever3
+	never = 40 // not of type Kind
+)
@@ -0,0 +1,52 @@
+package coverage
+
+type Direction int // want Direction:"^N,E,S,W$"
+
+const (
+	N Direction = iota
+	E
+	S
+	W
+)
+
+type Suit string // want Suit:"^Hearts,Spades$"
+
+const (
+	Hearts Suit = "hearts"
+	Spades Suit = "spades"
+)
+
+func _(d Direction, s Suit) {
+	switch d {
+	case N, E, S, W:
+	}
+
+	//exhaustive:ignore
+	switch d {
+	case N:
+	}
+
+	switch d {
+	case N:
+	}
+
+	switch s {
+	case Hearts:
+	default:
+	}
+
+	switch x := 1; x {
+	case 1:
+	}
+}
+
+var _ = map[Suit]int{
+	Hearts: 1,
+}
+
+var _ = map[Direction]int{N: 1, E: 2, S: 3, W: 4}
+
+// Counted for each enum type of the struct key's fields.
+var _ = map[struct{ S Suit }]int{
+	{Hearts}: 1,
+}
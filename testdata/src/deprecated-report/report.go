@@ -0,0 +1,31 @@
+package report
+
+import "deprecated/status"
+
+func _a(s status.Status) {
+	switch s {
+	case status.StatusEnabled, status.StatusPaused: // want "^status.StatusEnabled refers to deprecated enum member status.StatusEnabled$"
+	default:
+	}
+
+	// The replacement is already listed.
+	switch s {
+	case status.StatusActive, status.StatusPaused:
+	case status.StatusEnabled: // want "^status.StatusEnabled refers to deprecated enum member status.StatusEnabled$"
+	}
+
+	switch s {
+	case status.StatusActive, status.StatusHalted: // want "^status.StatusHalted refers to deprecated enum member status.StatusHalted$"
+	default:
+	}
+
+	switch s {
+	case status.StatusActive, status.StatusStopped: // want "^status.StatusStopped refers to deprecated enum member status.StatusStopped$"
+	default:
+	}
+
+	// No replacement.
+	switch s {
+	case status.StatusActive, status.StatusPaused, status.StatusOld: // want "^status.StatusOld refers to deprecated enum member status.StatusOld$"
+	}
+}
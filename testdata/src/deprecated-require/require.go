@@ -0,0 +1,9 @@
+package require
+
+import "deprecated/status"
+
+func _a(s status.Status) {
+	switch s { // want "^missing cases in switch of type status.Status: status.StatusEnabled, status.StatusOld, status.StatusHalted, status.StatusStopped$"
+	case status.StatusActive, status.StatusPaused:
+	}
+}
@@ -0,0 +1,18 @@
+package downstream
+
+import "deprecated/status"
+
+func _a(s status.Status) {
+	switch s {
+	case status.StatusActive, status.StatusPaused:
+	}
+
+	switch s { // want "^missing cases in switch of type status.Status: status.StatusPaused$"
+	case status.StatusActive:
+	}
+
+	_ = map[status.Status]string{
+		status.StatusActive: "active",
+		status.StatusPaused: "paused",
+	}
+}
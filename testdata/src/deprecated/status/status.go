@@ -0,0 +1,27 @@
+package status
+
+type Status int // want Status:"^StatusActive,StatusEnabled,StatusPaused,StatusOld,StatusHalted,StatusStopped$"
+
+const (
+	StatusActive Status = iota
+	// Deprecated: Use StatusActive instead.
+	StatusEnabled
+	StatusPaused
+	// StatusOld is an old status.
+	//
+	// Deprecated: No longer reported.
+	StatusOld
+)
+
+// Deprecated: Use [StatusPaused].
+const StatusHalted Status = 4
+
+// Deprecated: Use StatusPaused.
+const StatusStopped Status = 5
+
+func _a(s Status) {
+	// Deprecated members are required in the package of the enum type.
+	switch s { // want "^missing cases in switch of type status.Status: status.StatusOld, status.StatusHalted, status.StatusStopped$"
+	case StatusActive, StatusEnabled, StatusPaused:
+	}
+}
@@ -0,0 +1,57 @@
+package directivetypo
+
+type Direction int // want Direction:"^N,E,S,W$"
+
+const (
+	N Direction = iota
+	E
+	S
+	W
+)
+
+func _a(d Direction) {
+	// exhaustive:ignore
+	switch d { // want "^failed to parse directives: malformed directive \"// exhaustive:ignore\" \\(did you mean \"//exhaustive:ignore\"\\?\\)$"
+	case N, E, S, W:
+	}
+
+	//exhaustive: ignore
+	switch d { // want "^failed to parse directives: invalid directive \"\" \\(did you mean \"//exhaustive:ignore\"\\?\\)$"
+	case N, E, S, W:
+	}
+
+	//exhaustive:ignroe because reasons
+	switch d { // want "^failed to parse directives: invalid directive \"ignroe\" \\(did you mean \"//exhaustive:ignore because reasons\"\\?\\)$"
+	case N, E, S, W:
+	}
+
+	//Exhaustive : Enforce-Default-Case-Required
+	switch d { // want "^failed to parse directives: malformed directive \"//Exhaustive : Enforce-Default-Case-Required\" \\(did you mean \"//exhaustive:enforce-default-case-required\"\\?\\)$"
+	case N, E, S, W:
+	}
+
+	//exhaustive:ignore-default-case-requried
+	switch d { // want "^failed to parse directives: invalid directive \"ignore-default-case-requried\" \\(did you mean \"//exhaustive:ignore-default-case-required\"\\?\\)$"
+	case N, E, S, W:
+	}
+}
+
+func _b(d Direction) {
+	// No suggestion: not close to any directive name.
+	//exhaustive:foo
+	switch d { // want "^failed to parse directives: invalid directive \"foo\"$"
+	case N, E, S, W:
+	}
+
+	// Not directives.
+	// exhaustive: a description of the switch statement below
+	// exhaustiveness is checked for this switch statement
+	switch d {
+	case N, E, S, W:
+	}
+}
+
+//  exhaustive:ignore
+var _ = map[Direction]int{ // want "^failed to parse directives: malformed directive \"//  exhaustive:ignore\" \\(did you mean \"//exhaustive:ignore\"\\?\\)$"
+	N: 1, E: 2, S: 3, W: 4,
+}
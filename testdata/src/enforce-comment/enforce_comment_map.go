@@ -326,7 +326,7 @@ func localVarDeclaration() {
 
 func invalidDirectiveMap() {
 	//exhaustive:enfocre
-	var _ = map[Direction]int{ // want "^failed to parse directives: invalid directive \"enfocre\"$"
+	var _ = map[Direction]int{ // want "^failed to parse directives: invalid directive \"enfocre\" \\(did you mean \"//exhaustive:enforce\"\\?\\)$"
 		N: 1,
 	}
 }
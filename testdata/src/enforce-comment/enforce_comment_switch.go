@@ -87,7 +87,7 @@ func invalidDirectiveSwitch() {
 	var d Direction
 
 	//exhaustive:ingore
-	switch d { // want "^failed to parse directives: invalid directive \"ingore\"$"
+	switch d { // want "^failed to parse directives: invalid directive \"ingore\" \\(did you mean \"//exhaustive:ignore\"\\?\\)$"
 	case N:
 	}
 
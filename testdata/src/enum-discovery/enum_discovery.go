@@ -0,0 +1,42 @@
+package enumdiscovery
+
+type Port int
+
+const DefaultPort Port = 80
+
+//exhaustive:enum
+type Kind int // want Kind:"^KindA,KindB$"
+
+const (
+	KindA Kind = iota
+	KindB
+)
+
+// Annotated on the declaration.
+//
+//exhaustive:enum
+type (
+	Color int // want Color:"^Red,Green$"
+)
+
+const (
+	Red Color = iota
+	Green
+)
+
+func _a(p Port, k Kind, c Color) {
+	switch p {
+	case DefaultPort:
+	}
+
+	switch k { // want "^missing cases in switch of type enumdiscovery.Kind: enumdiscovery.KindB$"
+	case KindA:
+	}
+
+	switch c { // want "^missing cases in switch of type enumdiscovery.Color: enumdiscovery.Green$"
+	case Red:
+	}
+
+	const KindC Kind = 2 // want "^constant KindC of enum type enumdiscovery.Kind is declared outside the scope of the type and is not an enum member$"
+	const port Port = 8080
+}
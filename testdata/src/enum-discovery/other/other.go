@@ -0,0 +1,7 @@
+package other
+
+import enumdiscovery "enum-discovery"
+
+const KindD enumdiscovery.Kind = 3 // want "^constant KindD of enum type enumdiscovery.Kind is declared outside the scope of the type and is not an enum member$"
+
+const AltPort enumdiscovery.Port = 8080
@@ -0,0 +1,48 @@
+package emptybody
+
+import (
+	eco "fix-case-body"
+)
+
+type Direction int // want Direction:"^N,E,S,W$"
+
+const (
+	N Direction = iota
+	E
+	S
+	W
+)
+
+func _a(d Direction) {
+	switch d { // want "^missing cases in switch of type emptybody.Direction: emptybody.E, emptybody.W$"
+	case N:
+	case S:
+	}
+}
+
+func _b(b eco.Biome) {
+	switch b { // want "^missing cases in switch of type fcb.Biome: fcb.Savanna\\|fcb.Grassland, fcb.Desert$"
+	case eco.Tundra:
+		return
+	default:
+		panic(b)
+	}
+}
+
+func _c(b eco.Biome) {
+	switch b {} // want "^missing cases in switch of type fcb.Biome: fcb.Tundra, fcb.Savanna\\|fcb.Grassland, fcb.Desert$"
+}
+
+func _d(b eco.Biome) {
+	for {
+		switch b { // want "^missing cases in switch of type fcb.Biome: fcb.Desert$"
+		case eco.Tundra, eco.Savanna:
+		}
+	}
+}
+
+func _e[T eco.Other](v T) {
+	switch v { // want "^missing cases in switch of type fcb.Other: fcb.OtherB$"
+	case T(eco.OtherA):
+	}
+}
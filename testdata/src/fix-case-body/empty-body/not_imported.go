@@ -0,0 +1,12 @@
+package emptybody
+
+import (
+	"fix-case-body/reexport"
+)
+
+// No suggested fix: the file doesn't import the package of the enum
+// members.
+func _g() {
+	switch reexport.Biome() { // want "^missing cases in switch of type fcb.Biome: fcb.Tundra, fcb.Savanna\\|fcb.Grassland, fcb.Desert$"
+	}
+}
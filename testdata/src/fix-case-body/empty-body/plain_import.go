@@ -0,0 +1,12 @@
+package emptybody
+
+import (
+	"fix-case-body"
+)
+
+// The enum type's package is imported without a name.
+func _f(b fcb.Biome) {
+	switch b { // want "^missing cases in switch of type fcb.Biome: fcb.Desert$"
+	case fcb.Tundra, fcb.Savanna:
+	}
+}
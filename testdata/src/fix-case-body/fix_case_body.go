@@ -0,0 +1,19 @@
+package fcb
+
+type Biome int
+
+const (
+	Tundra Biome = iota
+	Savanna
+	Desert
+	Grassland = Savanna
+)
+
+type Other int8
+
+const (
+	OtherA Other = iota
+	OtherB
+)
+
+func Biomes() Biome { return Tundra }
@@ -0,0 +1,5 @@
+package reexport
+
+import fcb "fix-case-body"
+
+func Biome() fcb.Biome { return fcb.Tundra }
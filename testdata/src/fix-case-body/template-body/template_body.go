@@ -0,0 +1,47 @@
+package templatebody
+
+import (
+	eco "fix-case-body"
+)
+
+func _a(b eco.Biome) {
+	switch b { // want "^missing cases in switch of type fcb.Biome: fcb.Desert$"
+	case eco.Tundra, eco.Savanna:
+	}
+}
+
+func _b(x int) {
+	switch eco.Biome(x) { // want "^missing cases in switch of type fcb.Biome: fcb.Desert$"
+	case eco.Tundra, eco.Savanna:
+	}
+}
+
+// The switch tag has side effects, so the fix binds it to a variable.
+func _c() {
+	switch eco.Biomes() { // want "^missing cases in switch of type fcb.Biome: fcb.Desert$"
+	case eco.Tundra, eco.Savanna:
+	}
+}
+
+func _d(v int) {
+	switch eco.Biomes() { // want "^missing cases in switch of type fcb.Biome: fcb.Desert$"
+	case eco.Tundra, eco.Savanna:
+		println(v)
+	}
+}
+
+// No suggested fix: the switch tag has side effects and the init
+// statement is in use.
+func _e() {
+	switch x := 1; eco.Biome(x) + eco.Biomes() { // want "^missing cases in switch of type fcb.Biome: fcb.Desert$"
+	case eco.Tundra, eco.Savanna:
+	}
+}
+
+// No suggested fix: the operand of the tagless switch has side effects,
+// and the template refers to it.
+func _f() {
+	switch { // want "^missing cases in switch of type fcb.Biome: fcb.Savanna\\|fcb.Grassland, fcb.Desert$" "^cannot suggest fix: the fix template refers to the switch operand eco.Biomes\\(\\), which may have side effects$"
+	case eco.Biomes() == eco.Tundra:
+	}
+}
@@ -0,0 +1,19 @@
+package emptybody
+
+import fdb "fix-default-body"
+
+func _a(t fdb.T) {
+	switch t { // want "^missing default case in switch of type fdb.T$"
+	case fdb.A:
+	case fdb.B:
+	}
+}
+
+func _b(t fdb.T) {
+	switch t { // want "^missing default case in switch of type fdb.T$"
+	case fdb.A, fdb.B:
+		if t == fdb.A {
+			return
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package fdb
+
+type T int
+
+const (
+	A T = iota
+	B
+)
+
+func Get() T { return A }
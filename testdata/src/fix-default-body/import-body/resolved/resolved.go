@@ -0,0 +1,18 @@
+package resolved
+
+import (
+	fdb "fix-default-body"
+	mrand "math/rand"
+)
+
+var _ = mrand.Int
+
+// The fix imports package math/rand, which the file imports under another
+// name, and package errors, a standard library package. It does not
+// import err, which the statements declare.
+func _a(t fdb.T) {
+	switch t { // want "^missing default case in switch of type fdb.T$"
+	case fdb.A:
+	case fdb.B:
+	}
+}
@@ -0,0 +1,5 @@
+package unresolved
+
+import "math/rand"
+
+var _ = rand.Int
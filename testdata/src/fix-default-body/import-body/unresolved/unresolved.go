@@ -0,0 +1,13 @@
+package unresolved
+
+import fdb "fix-default-body"
+
+// No suggested fix: the file doesn't import a package named rand, though
+// another file in the package does, and rand is not a known standard
+// library package name, because more than one package has it.
+func _a(t fdb.T) {
+	switch t { // want "^missing default case in switch of type fdb.T$" "^cannot suggest fix: package rand, referred to by the fix template, is not imported by the file$"
+	case fdb.A:
+	case fdb.B:
+	}
+}
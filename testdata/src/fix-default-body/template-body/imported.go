@@ -0,0 +1,40 @@
+package templatebody
+
+import (
+	"fmt"
+
+	fdb "fix-default-body"
+)
+
+func _a(t fdb.T) {
+	fmt.Println(t)
+	switch t { // want "^missing default case in switch of type fdb.T$"
+	case fdb.A:
+	case fdb.B:
+	}
+}
+
+// The switch tag has side effects, so the fix binds it to a variable.
+func _b() {
+	switch fdb.Get() { // want "^missing default case in switch of type fdb.T$"
+	case fdb.A:
+	case fdb.B:
+	}
+}
+
+// No suggested fix: the switch tag has side effects and the init
+// statement is in use.
+func _c() {
+	switch x := 0; fdb.Get() + fdb.T(x) { // want "^missing default case in switch of type fdb.T$"
+	case fdb.A:
+	case fdb.B:
+	}
+}
+
+// No suggested fix: the operand of the tagless switch has side effects,
+// and the template refers to it.
+func _f() {
+	switch { // want "^missing default case in switch of type fdb.T$" "^cannot suggest fix: the fix template refers to the switch operand fdb.Get\\(\\), which may have side effects$"
+	case fdb.Get() == fdb.A:
+	}
+}
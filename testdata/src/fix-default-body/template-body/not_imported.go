@@ -0,0 +1,13 @@
+package templatebody
+
+import (
+	fdb "fix-default-body"
+)
+
+// The fix adds an import for package fmt.
+func _d(t fdb.T) {
+	switch t { // want "^missing default case in switch of type fdb.T$"
+	case fdb.A:
+	case fdb.B:
+	}
+}
@@ -0,0 +1,7 @@
+package templatebody
+
+import fdb "fix-default-body"
+
+func _e(t fdb.T) {
+	switch t {} // want "^missing default case in switch of type fdb.T$"
+}
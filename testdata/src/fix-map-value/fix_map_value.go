@@ -0,0 +1,12 @@
+package fmv
+
+type Status int
+
+const (
+	StatusOK Status = iota
+	StatusNotFound
+	HTTPVersionNotSupported
+	StatusFound = StatusOK
+)
+
+type Info struct{ Code int }
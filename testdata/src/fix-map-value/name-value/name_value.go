@@ -0,0 +1,11 @@
+package namevalue
+
+import (
+	"fix-map-value"
+)
+
+type display string
+
+var _ = map[fmv.Status]display{ // want "^missing keys in map of key type fmv.Status: fmv.StatusNotFound, fmv.HTTPVersionNotSupported$"
+	fmv.StatusOK: "StatusOK",
+}
@@ -0,0 +1,25 @@
+package snakevalue
+
+import (
+	status "fix-map-value"
+)
+
+type Local int // want Local:"^LocalA,localURLFormat$"
+
+const (
+	LocalA Local = iota
+	localURLFormat
+)
+
+var _ = map[status.Status]string{ // want "^missing keys in map of key type fmv.Status: fmv.StatusNotFound, fmv.HTTPVersionNotSupported$"
+	status.StatusOK: "ok",
+}
+
+var _ = map[Local]string{ // want "^missing keys in map of key type snakevalue.Local: snakevalue.localURLFormat$"
+	LocalA: "a",
+}
+
+// Not a string element type: uses the zero value.
+var _ = map[Local]float64{ // want "^missing keys in map of key type snakevalue.Local: snakevalue.localURLFormat$"
+	LocalA: 1,
+}
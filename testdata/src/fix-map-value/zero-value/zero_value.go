@@ -0,0 +1,32 @@
+package zerovalue
+
+import (
+	"fix-map-value"
+)
+
+type label string
+
+var _ = map[fmv.Status]int{ // want "^missing keys in map of key type fmv.Status: fmv.StatusNotFound, fmv.HTTPVersionNotSupported$"
+	fmv.StatusOK: 200,
+}
+
+var _ = map[fmv.Status]string{fmv.StatusOK: "ok"} // want "^missing keys in map of key type fmv.Status: fmv.StatusNotFound, fmv.HTTPVersionNotSupported$"
+
+var _ = map[fmv.Status]label{ // want "^missing keys in map of key type fmv.Status: fmv.StatusOK\\|fmv.StatusFound, fmv.HTTPVersionNotSupported$"
+	fmv.StatusNotFound: "not found",
+}
+
+var _ = map[fmv.Status]*fmv.Info{ // want "^missing keys in map of key type fmv.Status: fmv.StatusNotFound, fmv.HTTPVersionNotSupported$"
+	fmv.StatusOK: nil,
+}
+
+var _ = map[fmv.Status]fmv.Info{ // want "^missing keys in map of key type fmv.Status: fmv.HTTPVersionNotSupported$"
+	fmv.StatusOK: {}, fmv.StatusNotFound: {},
+}
+
+var _ = map[fmv.Status][2]bool{ // want "^missing keys in map of key type fmv.Status: fmv.StatusOK\\|fmv.StatusFound, fmv.StatusNotFound$"
+	fmv.HTTPVersionNotSupported: {true, false}}
+
+func _[T ~[]int](v T) {
+	_ = map[fmv.Status]T{fmv.StatusOK: v} // want "^missing keys in map of key type fmv.Status: fmv.StatusNotFound, fmv.HTTPVersionNotSupported$"
+}
@@ -0,0 +1,108 @@
+package flags
+
+//exhaustive:flags
+type Perm int // want Perm:"^Read,Write,Exec$"
+
+const (
+	Read Perm = 1 << iota
+	Write
+	Exec
+)
+
+//exhaustive:flags
+type Mode uint8 // want Mode:"^None,Bold,Italic,All$"
+
+const (
+	None   Mode = 0
+	Bold   Mode = 1
+	Italic Mode = 2
+	All         = Bold | Italic
+)
+
+func _a(p Perm, m Mode) {
+	switch p {
+	case Read:
+	case Write | Exec:
+	}
+
+	switch p { // want "^missing cases in switch of type flags.Perm: flags.Write, flags.Exec$"
+	case Read:
+	}
+
+	// Bits outside the mask need not be listed.
+	switch p & (Read | Write) {
+	case Read:
+	case Write:
+	}
+
+	switch m {
+	case All:
+	}
+
+	switch m { // want "^missing cases in switch of type flags.Mode: flags.Italic$"
+	case None, Bold:
+	}
+}
+
+func _b(p Perm) {
+	if p&Read != 0 {
+	} else if p&Write == Write {
+	} else if p&Exec > 0 {
+	}
+
+	if p&Read != 0 { // want "^missing cases in if-else chain of type flags.Perm: flags.Exec$"
+	} else if p&Write != 0 {
+	}
+
+	switch {
+	case p&Read != 0:
+	case p&(Write|Exec) == Write|Exec:
+	}
+
+	switch { // want "^missing cases in switch of type flags.Perm: flags.Exec$"
+	case p&Read != 0:
+	case p&Write != 0:
+	}
+}
+
+var _ = map[Perm]string{ // want "^missing keys in map of key type flags.Perm: flags.Exec$"
+	Read:         "r",
+	Read | Write: "rw",
+}
+
+type Color int // want Color:"^Red,Green$"
+
+const (
+	Red   Color = 1
+	Green Color = 2
+)
+
+func _c(c Color) {
+	// Bit tests of an enum that isn't a bit-flag enum aren't checked.
+	if c&Red != 0 {
+	} else if c&Green != 0 {
+	}
+
+	// Combinations of members don't account for members of an enum
+	// that isn't a bit-flag enum.
+	switch c { // want "^missing cases in switch of type flags.Color: flags.Red, flags.Green$"
+	case Red | Green:
+	}
+}
+
+// Without an "//exhaustive:flags" comment, an enum whose members look like
+// bit flags is an ordinary enum, so its zero member has to be listed.
+type Shift int // want Shift:"^ShiftNone,ShiftA,ShiftB,ShiftC$"
+
+const (
+	ShiftNone Shift = 0
+	ShiftA    Shift = 1
+	ShiftB    Shift = 2
+	ShiftC    Shift = 4
+)
+
+func _d(s Shift) {
+	switch s { // want "^missing cases in switch of type flags.Shift: flags.ShiftNone$"
+	case ShiftA, ShiftB, ShiftC:
+	}
+}
@@ -10,13 +10,21 @@ const (
 )
 
 func _c() {
-	// Tagless switch -- should be ignored.
+	// Tagless switch whose cases compare an enum value against enum
+	// members -- checked like a tagged switch. See tagless-switch testdata.
 
 	var p barpkg.Phylum
-	switch {
+	switch { // want "^missing cases in switch of type bar.Phylum: bar.Mollusca$"
 	case p == barpkg.Chordata:
 	case p == barpkg.Echinodermata:
 	}
+
+	// Other tagless switches -- should be ignored.
+
+	switch {
+	case p == barpkg.Chordata:
+	case PlainIntA == 1:
+	}
 }
 
 func _d() {
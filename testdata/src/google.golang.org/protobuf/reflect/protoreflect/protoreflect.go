@@ -0,0 +1,15 @@
+// Package protoreflect is a stub of the package of the same name in the
+// google.golang.org/protobuf module.
+package protoreflect
+
+type EnumNumber int32
+
+type EnumDescriptor interface{ FullName() string }
+
+type EnumType interface{ Descriptor() EnumDescriptor }
+
+type Enum interface {
+	Descriptor() EnumDescriptor
+	Type() EnumType
+	Number() EnumNumber
+}
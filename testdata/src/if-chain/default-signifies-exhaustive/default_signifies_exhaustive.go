@@ -0,0 +1,14 @@
+package defaultsignifiesexhaustive
+
+import "if-chain"
+
+func _a(k ifchain.Kind) {
+	if k == ifchain.A {
+	} else if k == ifchain.B {
+	} else {
+	}
+
+	if k == ifchain.A { // want "^missing cases in if-else chain of type ifchain.Kind: ifchain.C, ifchain.D$"
+	} else if k == ifchain.B {
+	}
+}
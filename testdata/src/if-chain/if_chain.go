@@ -0,0 +1,74 @@
+package ifchain
+
+type Kind int // want Kind:"^A,B,C,D$"
+
+const (
+	A Kind = iota
+	B
+	C
+	D
+)
+
+type T struct{ k Kind }
+
+func _a(k Kind, t T, x int) {
+	if k == A { // want "^missing cases in if-else chain of type ifchain.Kind: ifchain.D$"
+	} else if k == B || k == C {
+	}
+
+	if k == A {
+	} else if B == k {
+	} else if k == C || (k == D) {
+	}
+
+	if t.k == A { // want "^missing cases in if-else chain of type ifchain.Kind: ifchain.B, ifchain.C$"
+	} else if t.k == D {
+	} else {
+	}
+
+	// A lone if statement is not a chain.
+	if k == A {
+	}
+
+	// Different operands.
+	if k == A {
+	} else if t.k == B {
+	}
+
+	// Not all conditions are comparisons against members.
+	if k == A {
+	} else if k == B && x == 1 {
+	}
+	if k == A {
+	} else if x == 1 {
+	}
+	if k == A {
+	} else if k != B {
+	}
+
+	//exhaustive:ignore
+	if k == A {
+	} else if k == B {
+	}
+
+	// Nested chains are checked.
+	if x == 1 {
+		if k == A { // want "^missing cases in if-else chain of type ifchain.Kind: ifchain.C, ifchain.D$"
+		} else if k == B {
+		}
+	} else if x == 2 {
+	}
+}
+
+func f() Kind { return A }
+
+func _b() {
+	// Calls may have side effects, so are not supported operands.
+	if f() == A {
+	} else if f() == B {
+	}
+
+	if k := f(); k == A { // want "^missing cases in if-else chain of type ifchain.Kind: ifchain.C, ifchain.D$"
+	} else if k == B {
+	}
+}
@@ -0,0 +1,68 @@
+package inventory
+
+import (
+	"errors"
+
+	"inventory/other"
+)
+
+// Color is a color.
+type Color int
+
+const (
+	// Red is red.
+	Red Color = iota
+	Green
+	// Deprecated: Use Green instead.
+	Lime
+	numColors
+)
+
+type Shape string
+
+const Circle Shape = "circle"
+
+//exhaustive:ignore
+type Ignored int
+
+const IgnoredA Ignored = 0
+
+type NotEnum struct{}
+
+// Invalid directives are reported by Analyzer, not InventoryAnalyzer.
+//
+//exhaustive:ignor
+type Typo int
+
+const TypoA Typo = 0
+
+// Status is a status.
+//
+//exhaustive:enum
+type Status struct{ code int }
+
+var (
+	// OK is ok.
+	OK    = &Status{0}
+	Error = &Status{1}
+)
+
+// Errors are the errors.
+//
+//exhaustive:enum
+var (
+	ErrA = errors.New("a")
+	ErrB = errors.New("b")
+)
+
+func _() {
+	// Invalid directives are reported by Analyzer, not InventoryAnalyzer.
+	//
+	//exhaustive:member-of other.Kind
+	const Local other.Kind = 101
+}
+
+// Custom is a kind.
+//
+//exhaustive:member-of other.Kind
+const Custom other.Kind = 100
@@ -0,0 +1,8 @@
+package other
+
+type Kind int
+
+const (
+	KindA Kind = iota
+	KindB
+)
@@ -0,0 +1,75 @@
+package mapassign
+
+type Kind int // want Kind:"^A,B,C$"
+
+const (
+	A Kind = iota
+	B
+	C
+)
+
+//exhaustive:enforce
+var names = make(map[Kind]string) // want "^missing keys assigned to map names of key type mapassign.Kind: mapassign.C$"
+
+var (
+	//exhaustive:enforce
+	counts map[Kind]int
+	// Not enforced.
+	other = map[Kind]int{}
+)
+
+func init() {
+	names[A] = "a"
+	counts = make(map[Kind]int)
+	counts[A], counts[B] = 1, 2
+	other[A] = 1
+}
+
+func init() {
+	names[B] = "b"
+	counts[C]++
+}
+
+func _a(k Kind) map[Kind]string {
+	//exhaustive:enforce
+	m := make(map[Kind]string) // want "^missing keys assigned to map m of key type mapassign.Kind: mapassign.B, mapassign.C$"
+	m[A] = "a"
+	m[k] = "k"
+	names[C] = "not an init function"
+	return m
+}
+
+func _b() {
+	//exhaustive:enforce
+	m := map[Kind]bool{}
+	m[A] = true
+	func() {
+		m[B] = true
+	}()
+	m[C] = true
+
+	//exhaustive:enforce ... an optional explanation
+	var n = make(map[Kind]bool, 3) // want "^missing keys assigned to map n of key type mapassign.Kind: mapassign.A, mapassign.C$"
+	n[B] = true
+
+	// Not checked: the initial value has keys, and is checked as a map
+	// literal instead.
+	//exhaustive:enforce
+	o := map[Kind]bool{A: true} // want "^missing keys in map of key type mapassign.Kind: mapassign.B, mapassign.C$"
+	o[B] = true
+
+	// Not checked: the key type isn't an enum.
+	//exhaustive:enforce
+	p := make(map[int]bool)
+	p[1] = true
+
+	q := make(map[Kind]bool)
+	q[A] = true
+}
+
+func _c() {
+	// A malformed directive is reported rather than ignored.
+	//exhaustive:enforc
+	m := make(map[Kind]bool) // want "^failed to parse directives: invalid directive \"enforc\" \\(did you mean \"//exhaustive:enforce\"\\?\\)$"
+	m[A] = true
+}
@@ -0,0 +1,49 @@
+package matchvalues
+
+import "match-values/wire"
+
+type Opcode uint8 // want Opcode:"^Nop,Load,Store,Jump,opcodeMax$"
+
+const (
+	Nop Opcode = iota
+	Load
+	Store
+	Jump
+	opcodeMax
+)
+
+func _a(op Opcode) {
+	switch op { // want "^missing cases in switch of type matchvalues.Opcode: matchvalues.opcodeMax$"
+	case Nop:
+	case 1:
+	case Load + 1:
+	case Opcode(3):
+	}
+
+	// A switch statement that lists each value is exhaustive, as is
+	// common in code that decodes wire formats.
+	switch op {
+	case 0, 1, 2, 3, 4:
+	}
+
+	// A value that isn't the value of a member satisfies nothing.
+	switch op { // want "^missing cases in switch of type matchvalues.Opcode: matchvalues.Jump, matchvalues.opcodeMax$"
+	case Nop, Load, Store:
+	case 9:
+	}
+}
+
+var _ = map[Opcode]string{ // want "^missing keys in map of key type matchvalues.Opcode: matchvalues.Store, matchvalues.Jump, matchvalues.opcodeMax$"
+	Nop: "nop",
+	1:   "load",
+}
+
+var _ = map[Opcode]string{0: "nop", 1: "load", 2: "store", 3: "jump", 4: "max"}
+
+func _b(k wire.Kind) {
+	// Unexported members of other packages need not be listed.
+	switch k {
+	case wire.A:
+	case 1:
+	}
+}
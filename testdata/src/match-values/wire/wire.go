@@ -0,0 +1,9 @@
+package wire
+
+type Kind int // want Kind:"^A,B,c$"
+
+const (
+	A Kind = iota
+	B
+	c
+)
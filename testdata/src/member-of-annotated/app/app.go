@@ -0,0 +1,16 @@
+package app
+
+import (
+	"member-of-annotated/core"
+	"member-of-annotated/plugin"
+)
+
+func _a(k core.Kind) {
+	switch k { // want "^missing cases in switch of type core.Kind: plugin.Custom$"
+	case core.KindA, core.KindB:
+	}
+
+	switch k {
+	case core.KindA, core.KindB, plugin.Custom:
+	}
+}
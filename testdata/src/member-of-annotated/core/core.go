@@ -0,0 +1,9 @@
+package core
+
+//exhaustive:enum
+type Kind int // want Kind:"^KindA,KindB$"
+
+const (
+	KindA Kind = iota
+	KindB
+)
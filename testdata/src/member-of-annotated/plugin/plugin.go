@@ -0,0 +1,16 @@
+package plugin // want package:"^member-of-annotated/core.Kind:Custom$"
+
+import "member-of-annotated/core"
+
+// Extension members of an annotated enum type are enum members.
+//
+//exhaustive:member-of core.Kind
+const Custom core.Kind = 100
+
+const NotMember core.Kind = 101 // want "^constant NotMember of enum type core.Kind is declared outside the scope of the type and is not an enum member$"
+
+func _a(k core.Kind) {
+	switch k { // want "^missing cases in switch of type core.Kind: plugin.Custom$"
+	case core.KindA, core.KindB:
+	}
+}
@@ -0,0 +1,16 @@
+package app
+
+import (
+	"member-of/core"
+	"member-of/plugin"
+)
+
+func _a(k core.Kind) {
+	switch k { // want "^missing cases in switch of type core.Kind: plugin.Custom, plugin.Other$"
+	case core.KindA, core.KindB:
+	}
+
+	switch k {
+	case core.KindA, core.KindB, plugin.Custom, plugin.Other:
+	}
+}
@@ -0,0 +1,15 @@
+package core
+
+type Kind int // want Kind:"^KindA,KindB$"
+
+const (
+	KindA Kind = iota
+	KindB
+)
+
+func _a(k Kind) {
+	// Extension members aren't visible in the package of the enum type.
+	switch k {
+	case KindA, KindB:
+	}
+}
@@ -0,0 +1,11 @@
+package other
+
+import "member-of/core"
+
+// The package doesn't depend on the plugin package, so the extension
+// members aren't required.
+func _a(k core.Kind) {
+	switch k {
+	case core.KindA, core.KindB:
+	}
+}
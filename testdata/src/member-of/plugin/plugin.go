@@ -0,0 +1,46 @@
+package plugin // want package:"^member-of/core.Kind:Custom,Other$"
+
+import "member-of/core"
+
+//exhaustive:member-of core.Kind
+const Custom core.Kind = 100
+
+//exhaustive:member-of core.Kind
+const (
+	Other core.Kind = 101
+	_     core.Kind = 102
+)
+
+const NotMember core.Kind = 103
+
+//exhaustive:member-of core.Kind // want "^failed to parse directives: constant Wrong is not of type core.Kind$"
+const Wrong = 104
+
+//exhaustive:member-of Kind // want "^failed to parse directives: \"member-of\" directive requires a package-qualified type name, got \"Kind\"$"
+const Unqualified core.Kind = 105
+
+//exhaustive:member-of kind.Kind // want "^failed to parse directives: unknown package \"kind\" in \"member-of\" directive$"
+const UnknownPackage core.Kind = 106
+
+//exhaustive:member-of core.Knd // want "^failed to parse directives: unknown type \"core.Knd\" in \"member-of\" directive$"
+const UnknownType core.Kind = 107
+
+func _a(k core.Kind) {
+	switch k { // want "^missing cases in switch of type core.Kind: plugin.Other$"
+	case core.KindA, core.KindB, Custom:
+	}
+
+	_ = map[core.Kind]int{ // want "^missing keys in map of key type core.Kind: core.KindB, plugin.Custom$"
+		core.KindA: 1,
+		Other:      2,
+	}
+}
+
+func _b(k core.Kind) {
+	//exhaustive:member-of core.Kind // want "^failed to parse directives: \"member-of\" directive applies only to package-level constants$"
+	const Local core.Kind = 108
+
+	switch k { // want "^missing cases in switch of type core.Kind: plugin.Custom, plugin.Other$"
+	case core.KindA, core.KindB, Local:
+	}
+}
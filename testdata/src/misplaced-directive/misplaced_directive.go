@@ -0,0 +1,51 @@
+package misplaceddirective
+
+type Direction int // want Direction:"^N,E,S,W$"
+
+const (
+	N Direction = iota
+	E
+	S
+	W
+)
+
+// Directives that apply only to declarations are invalid on the program
+// elements that are checked.
+func _a(d Direction) {
+	//exhaustive:group cardinal N,E,S,W
+	switch d { // want "^failed to parse directives: \"group\" directive does not apply to a switch statement$"
+	case N, E, S, W:
+	}
+
+	//exhaustive:flags
+	switch d { // want "^failed to parse directives: \"flags\" directive does not apply to a switch statement$"
+	case N, E, S, W:
+	}
+
+	//exhaustive:exclude {N}
+	switch d { // want "^failed to parse directives: \"exclude\" directive does not apply to a switch statement$"
+	case N, E, S, W:
+	}
+}
+
+//exhaustive:sealed
+var _ = map[Direction]int{N: 1, E: 2, S: 3, W: 4} // want "^failed to parse directives: \"sealed\" directive does not apply to a map literal$"
+
+// An exclude directive applies to a map literal with product keys.
+//
+//exhaustive:exclude (N, E) (N, S) (N, W) (E, *) (S, *) (W, *)
+var _ = map[struct{ From, To Direction }]int{
+	{N, N}: 1,
+}
+
+//exhaustive:enum
+var _ = []string{N: "n", E: "e", S: "s", W: "w"} // want "^failed to parse directives: \"enum\" directive does not apply to an array or slice literal$"
+
+func _b(d Direction) {
+	//exhaustive:member-of misplaceddirective.Direction
+	if d == N { // want "^failed to parse directives: \"member-of\" directive does not apply to an if-else chain$"
+	} else if d == E {
+	} else if d == S {
+	} else if d == W {
+	}
+}
@@ -0,0 +1,15 @@
+package downstream
+
+import namedgroups "named-groups"
+
+func _a(s namedgroups.State) {
+	//exhaustive:enforce group=active
+	switch s { // want "^missing cases in switch of type namedgroups.State: namedgroups.Running$"
+	case namedgroups.Idle:
+	}
+
+	//exhaustive:enforce group=active
+	switch s {
+	case namedgroups.Idle, namedgroups.Running:
+	}
+}
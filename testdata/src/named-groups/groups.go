@@ -0,0 +1,62 @@
+package namedgroups
+
+//exhaustive:group terminal Done,Failed,Cancelled
+//exhaustive:group active Idle,Running
+//exhaustive:group settled Done, Failed ,Cancelled and the rest is ignored
+type State int // want State:"^Idle,Running,Done,Failed,Cancelled$"
+
+const (
+	Idle State = iota
+	Running
+	Done
+	Failed
+	Cancelled
+)
+
+//exhaustive:group bad Red,Purple // want "^failed to parse directives: Purple in group \"bad\" is not an enum member$"
+//exhaustive:group primary Red,Green
+//exhaustive:group primary Red // want "^failed to parse directives: duplicate group \"primary\"$"
+type Color int // want Color:"^Red,Green$"
+
+const (
+	Red Color = iota
+	Green
+)
+
+//exhaustive:group some A,B // want "^failed to parse directives: type Port is not an enum type$"
+type Port int
+
+func _a(s State) {
+	//exhaustive:enforce group=terminal
+	switch s { // want "^missing cases in switch of type namedgroups.State: namedgroups.Cancelled$"
+	case Done, Failed:
+	}
+
+	//exhaustive:enforce group=terminal
+	switch s {
+	case Done, Failed, Cancelled:
+	}
+
+	//exhaustive:enforce group=terminal
+	switch s {
+	case Done, Failed, Cancelled:
+	case Running: // want "^case Running is not in group \"terminal\" of enum type namedgroups.State$"
+	}
+
+	//exhaustive:enforce group=finished
+	switch s { // want "^failed to parse directives: unknown group \"finished\" of enum type namedgroups.State$"
+	case Done:
+	}
+
+	// The members of a group may be separated by spaces after or
+	// before the commas.
+	//exhaustive:enforce group=settled
+	switch s { // want "^missing cases in switch of type namedgroups.State: namedgroups.Cancelled$"
+	case Done, Failed:
+	}
+
+	// Without a group, every member has to be listed.
+	switch s { // want "^missing cases in switch of type namedgroups.State: namedgroups.Idle, namedgroups.Running$"
+	case Done, Failed, Cancelled:
+	}
+}
@@ -0,0 +1,102 @@
+package productkeys
+
+type State int // want State:"^Idle,Running,Stopped$"
+
+const (
+	Idle State = iota
+	Running
+	Stopped
+)
+
+type Event int // want Event:"^Start,Stop$"
+
+const (
+	Start Event = iota
+	Stop
+)
+
+type Transition struct {
+	From State
+	To   State
+}
+
+type Key struct {
+	S State
+	E Event
+}
+
+var _ = map[Key]string{ // want "^missing combinations in map of key type \\(productkeys.State, productkeys.Event\\): \\(productkeys.Idle, productkeys.Stop\\), \\(productkeys.Running, \\*\\), \\(productkeys.Stopped, productkeys.Start\\)$"
+	{Idle, Start}:   "a",
+	{Stopped, Stop}: "b",
+}
+
+var _ = map[Key]string{
+	{Idle, Start}:          "a",
+	{Idle, Stop}:           "b",
+	{E: Start, S: Running}: "c",
+	Key{Running, Stop}:     "d",
+	{Stopped, Start}:       "e",
+	{Stopped, Stop}:        "f",
+}
+
+var _ = map[struct{ From, To State }]bool{ // want "^missing combinations in map of key type \\(productkeys.State, productkeys.State\\): \\(productkeys.Idle, productkeys.Idle\\), \\(productkeys.Running, productkeys.Running\\), \\(productkeys.Stopped, \\*\\)$"
+	{Idle, Running}:    true,
+	{Idle, Stopped}:    true,
+	{Running, Idle}:    true,
+	{Running, Stopped}: true,
+}
+
+// Invalid combinations can be excluded.
+//
+//exhaustive:exclude (Idle, Idle) (Running, Running)
+//exhaustive:exclude (productkeys.Stopped, *)
+var _ = map[Transition]bool{
+	{Idle, Running}:    true,
+	{Idle, Stopped}:    true,
+	{Running, Idle}:    true,
+	{Running, Stopped}: true,
+}
+
+//exhaustive:exclude (Idle, Paused)
+var _ = map[Transition]bool{ // want "^failed to parse directives: exclusion \\(Idle, Paused\\): Paused is not a member of productkeys.State$" "^missing combinations in map of key type \\(productkeys.State, productkeys.State\\): \\(productkeys.Idle, \\*\\), \\(productkeys.Running, productkeys.Idle\\), \\(productkeys.Running, productkeys.Running\\), \\(productkeys.Stopped, \\*\\)$"
+	{Running, Stopped}: true,
+}
+
+func _a(k Key) {
+	// Keys that aren't constant aren't checked.
+	_ = map[Key]string{
+		k:             "a",
+		{Idle, Start}: "b",
+	}
+}
+
+var _ = map[State]map[Event]State{ // want "^missing combinations in map of key type \\(productkeys.State, productkeys.Event\\): \\(productkeys.Idle, productkeys.Stop\\), \\(productkeys.Stopped, \\*\\)$"
+	Idle:    {Start: Running},
+	Running: {Start: Running, Stop: Stopped},
+	Stopped: {},
+}
+
+func _b(m map[Event]State) {
+	// A map whose inner maps aren't all literals isn't checked as a product.
+	_ = map[State]map[Event]State{ // want "^missing keys in map of key type productkeys.State: productkeys.Stopped$"
+		Idle:    m,
+		Running: {Start: Running},
+	}
+}
+
+// An ignored map literal is not checked, so an invalid exclusion in it is
+// not reported, even with the -report-unnecessary-ignore flag.
+//
+//exhaustive:ignore
+//exhaustive:exclude (Idle, Paused)
+var _ = map[Transition]bool{
+	{Idle, Idle}:       true,
+	{Idle, Running}:    true,
+	{Idle, Stopped}:    true,
+	{Running, Idle}:    true,
+	{Running, Running}: true,
+	{Running, Stopped}: true,
+	{Stopped, Idle}:    true,
+	{Stopped, Running}: true,
+	{Stopped, Stopped}: true,
+}
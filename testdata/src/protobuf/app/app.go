@@ -0,0 +1,64 @@
+package app
+
+import "protobuf/pb"
+
+// Mode isn't a protobuf enum, so its zero member has to be listed.
+type Mode int // want Mode:"^Mode_UNSPECIFIED,Mode_ON$"
+
+const (
+	Mode_UNSPECIFIED Mode = iota
+	Mode_ON
+)
+
+// shape is a sealed interface that isn't a oneof interface.
+type shape interface{ area() float64 } // want shape:"^circle$"
+
+type circle struct{}
+
+func (circle) area() float64 { return 0 }
+
+func _a(c pb.Color, m Mode) {
+	// The unspecified, deprecated, and reserved members need not be
+	// listed.
+	switch c {
+	case pb.Color_RED, pb.Color_GREEN:
+	}
+
+	switch c { // want "^missing cases in switch of type pb.Color: pb.Color_GREEN$"
+	case pb.Color_COLOR_UNSPECIFIED, pb.Color_RED:
+	}
+
+	_ = map[pb.Color]string{ // want "^missing keys in map of key type pb.Color: pb.Color_GREEN$"
+		pb.Color_RED: "red",
+	}
+
+	switch m { // want "^missing cases in switch of type app.Mode: app.Mode_UNSPECIFIED$"
+	case Mode_ON:
+	}
+}
+
+func _c(s pb.Status) {
+	// A member whose name merely has the word RESERVED is a real member.
+	switch s { // want "^missing cases in switch of type pb.Status: pb.Status_STATUS_RESERVED_FOR_ADMIN$"
+	case pb.Status_STATUS_ACTIVE:
+	}
+
+	switch s {
+	case pb.Status_STATUS_ACTIVE, pb.Status_STATUS_RESERVED_FOR_ADMIN:
+	}
+}
+
+func _b(e *pb.Event, s shape) {
+	switch e.GetPayload().(type) { // want "^missing cases in type switch of type pb.isEvent_Payload: \\*pb.Event_Key$"
+	case *pb.Event_Click:
+	}
+
+	switch e.GetPayload().(type) {
+	case *pb.Event_Click, *pb.Event_Key:
+	}
+
+	// Type switches over other sealed interfaces aren't checked unless
+	// type switches are.
+	switch s.(type) {
+	}
+}
@@ -0,0 +1,42 @@
+package reportmembervalues
+
+import "report-member-values/wire"
+
+type Opcode uint8 // want Opcode:"^Nop,Load,Store,Jump,opcodeMax$"
+
+const (
+	Nop Opcode = iota
+	Load
+	Store
+	Jump
+	opcodeMax
+)
+
+func _a(op Opcode) {
+	switch op { // want "^missing cases in switch of type reportmembervalues.Opcode: reportmembervalues.opcodeMax$"
+	case Nop:
+	case 1: // want "^1 has the value of enum member reportmembervalues.Load$"
+	case Load + 1: // want "^Load \\+ 1 has the value of enum member reportmembervalues.Store$"
+	case Opcode(3): // want "^Opcode\\(3\\) has the value of enum member reportmembervalues.Jump$"
+	}
+
+	// A value that isn't the value of a member satisfies nothing.
+	switch op { // want "^missing cases in switch of type reportmembervalues.Opcode: reportmembervalues.Jump, reportmembervalues.opcodeMax$"
+	case Nop, Load, Store:
+	case 9:
+	}
+}
+
+var _ = map[Opcode]string{ // want "^missing keys in map of key type reportmembervalues.Opcode: reportmembervalues.Store, reportmembervalues.Jump, reportmembervalues.opcodeMax$"
+	Nop: "nop",
+	1:   "load", // want "^1 has the value of enum member reportmembervalues.Load$"
+}
+
+func _b(k wire.Kind) {
+	// Unexported members of other packages are not suggested.
+	switch k {
+	case wire.A:
+	case 1: // want "^1 has the value of enum member wire.B$"
+	case 2:
+	}
+}
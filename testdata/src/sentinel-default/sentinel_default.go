@@ -0,0 +1,16 @@
+package sentineldefault
+
+type Position int // want Position:"^First,Middle,Last$"
+
+const (
+	First Position = iota
+	Middle
+	Last
+)
+
+// Without the -sentinel-pattern flag, no member is a sentinel.
+func _a(p Position) {
+	switch p { // want "^missing cases in switch of type sentineldefault.Position: sentineldefault.Last$"
+	case First, Middle:
+	}
+}
@@ -0,0 +1,16 @@
+package sentinelpattern
+
+type Kind int // want Kind:"^KindA,KindB,NumKinds,KindSentinel$"
+
+const (
+	KindA Kind = iota
+	KindB
+	NumKinds
+	KindSentinel = NumKinds
+)
+
+func _a(k Kind) {
+	switch k { // want "^missing cases in switch of type sentinelpattern.Kind: sentinelpattern.NumKinds$"
+	case KindA, KindB:
+	}
+}
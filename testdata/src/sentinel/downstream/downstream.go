@@ -0,0 +1,13 @@
+package downstream
+
+import "sentinel"
+
+func _a(k sentinel.Kind) {
+	switch k {
+	case sentinel.KindA, sentinel.KindB, sentinel.KindC:
+	}
+
+	switch k { // want "^missing cases in switch of type sentinel.Kind: sentinel.KindC$"
+	case sentinel.KindA, sentinel.KindB:
+	}
+}
@@ -0,0 +1,56 @@
+package sentinel
+
+type Kind int // want Kind:"^KindA,KindB,KindC,NumKinds$"
+
+const (
+	KindA Kind = iota
+	KindB
+	KindC
+	NumKinds
+)
+
+type Level int // want Level:"^Low,High,maxLevel$"
+
+const (
+	_ Level = iota
+	Low
+	High
+	maxLevel = High
+)
+
+// Last is not trailing, so it is not a sentinel.
+type Position int // want Position:"^First,Last,Middle$"
+
+const (
+	First Position = iota
+	Last
+	Middle
+)
+
+// ColorMax is not consistent with a count or maximum, so it is not a
+// sentinel.
+type Color int // want Color:"^Red,Green,ColorMax$"
+
+const (
+	Red      Color = 1
+	Green    Color = 2
+	ColorMax Color = 10
+)
+
+func _a(k Kind, l Level, p Position, c Color) {
+	switch k {
+	case KindA, KindB, KindC:
+	}
+
+	switch l { // want "^missing cases in switch of type sentinel.Level: sentinel.High$"
+	case Low:
+	}
+
+	switch p { // want "^missing cases in switch of type sentinel.Position: sentinel.Middle$"
+	case First, Last:
+	}
+
+	switch c { // want "^missing cases in switch of type sentinel.Color: sentinel.ColorMax$"
+	case Red, Green:
+	}
+}
@@ -0,0 +1,51 @@
+package taglessswitch
+
+type Kind int // want Kind:"^A,B,C,D$"
+
+const (
+	A Kind = iota
+	B
+	C
+	D
+)
+
+type T struct{ k Kind }
+
+func _a(k Kind, t T, x int) {
+	switch { // want "^missing cases in switch of type taglessswitch.Kind: taglessswitch.D$"
+	case k == A:
+	case k == B, C == k:
+	}
+
+	switch {
+	case k == A || k == B:
+	case k == C, (k == D):
+	}
+
+	switch { // want "^missing cases in switch of type taglessswitch.Kind: taglessswitch.B, taglessswitch.C$"
+	case t.k == A:
+	case t.k == D:
+	default:
+	}
+
+	// Different operands.
+	switch {
+	case k == A:
+	case t.k == B:
+	}
+
+	// Not all cases are comparisons against members.
+	switch {
+	case k == A:
+	case x == 1:
+	}
+	switch {
+	case k == A:
+	case k != B:
+	}
+
+	//exhaustive:ignore
+	switch {
+	case k == A:
+	}
+}
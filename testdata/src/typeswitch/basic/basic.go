@@ -0,0 +1,68 @@
+package basic
+
+import "typeswitch/shape"
+
+func _a(s shape.Shape) {
+	switch s.(type) { // want "^missing cases in type switch of type shape.Shape: \\*shape.Square$"
+	case shape.Circle:
+	}
+
+	switch s.(type) {
+	case shape.Circle, *shape.Square:
+	}
+
+	// The pointer type of a value implementation doesn't list it, since
+	// a shape.Shape can hold values of both types.
+	switch s.(type) { // want "^missing cases in type switch of type shape.Shape: shape.Circle$"
+	case *shape.Circle, *shape.Square:
+	}
+
+	// Listing an interface type satisfies exhaustiveness for the types
+	// that implement it.
+	switch s.(type) {
+	case shape.Stringer, *shape.Square:
+	}
+
+	switch v := s.(type) { // want "^missing cases in type switch of type shape.Shape: shape.Circle$"
+	case nil:
+		_ = v
+	case *shape.Square:
+	default:
+	}
+
+	//exhaustive:ignore
+	switch s.(type) {
+	}
+}
+
+func _b(e shape.Event, st shape.Stringer, x interface{}) {
+	switch e.(type) { // want "^missing cases in type switch of type shape.Event: \\*shape.Click, \\*shape.Scroll$"
+	}
+
+	switch st.(type) {
+	case shape.Circle:
+	}
+
+	switch x.(type) {
+	case int:
+	}
+}
+
+type node interface { // want node:"^\\*leaf,\\*branch$"
+	node()
+}
+
+type leaf struct{}
+
+func (*leaf) node() {}
+
+type branch struct{ children []node }
+
+func (*branch) node() {}
+
+func _c(n node) {
+	switch n := n.(type) { // want "^missing cases in type switch of type basic.node: \\*basic.branch$"
+	case *leaf:
+		_ = n
+	}
+}
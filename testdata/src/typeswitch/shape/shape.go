@@ -0,0 +1,40 @@
+package shape
+
+// Shape is sealed by its unexported marker method.
+type Shape interface {
+	isShape()
+}
+
+type Circle struct{}
+
+func (Circle) isShape() {}
+
+type Square struct{}
+
+func (*Square) isShape() {}
+
+type triangle struct{}
+
+func (triangle) isShape() {}
+
+// Event is sealed by the directive.
+//
+//exhaustive:sealed
+type Event interface {
+	Name() string
+}
+
+type Click struct{}
+
+func (*Click) Name() string { return "click" }
+
+type Scroll struct{}
+
+func (*Scroll) Name() string { return "scroll" }
+
+// Stringer isn't sealed.
+type Stringer interface {
+	String() string
+}
+
+func (Circle) String() string { return "circle" }
@@ -0,0 +1,15 @@
+package templatebody
+
+import (
+	"fmt"
+	"typeswitch/shape"
+)
+
+var _ = fmt.Sprint
+
+func _b(e shape.Event) {
+	switch ev := e.(type) { // want "^missing cases in type switch of type shape.Event: \\*shape.Scroll$"
+	case *shape.Click:
+		println(ev)
+	}
+}
@@ -0,0 +1,13 @@
+package templatebody
+
+import (
+	"typeswitch/shape"
+)
+
+// The type switch guard doesn't declare a variable, so the fix declares
+// one for the template to refer to.
+func _a(s shape.Shape) {
+	switch s.(type) { // want "^missing cases in type switch of type shape.Shape: \\*shape.Square$"
+	case shape.Circle:
+	}
+}
@@ -0,0 +1,10 @@
+package unnecessaryignore
+
+type Direction int // want Direction:"^N,E,S,W$"
+
+const (
+	N Direction = iota
+	E
+	S
+	W
+)
@@ -0,0 +1,20 @@
+package unnecessaryignore
+
+// The ignore comment applies to the nested array literals too, and is
+// necessary because the nested literal for index W is not exhaustive.
+//
+//exhaustive:ignore
+var _ = [W + 1][W + 1]string{
+	N: {N: "n", E: "e", S: "s", W: "w"},
+	E: {N: "n", E: "e", S: "s", W: "w"},
+	S: {N: "n", E: "e", S: "s", W: "w"},
+	W: {N: "n"},
+}
+
+//exhaustive:ignore // want "^unnecessary //exhaustive:ignore directive$"
+var _ = [W + 1][W + 1]string{
+	N: {N: "n", E: "e", S: "s", W: "w"},
+	E: {N: "n", E: "e", S: "s", W: "w"},
+	S: {N: "n", E: "e", S: "s", W: "w"},
+	W: {N: "n", E: "e", S: "s", W: "w"},
+}
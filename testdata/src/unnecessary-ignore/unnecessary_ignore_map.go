@@ -0,0 +1,38 @@
+package unnecessaryignore
+
+//exhaustive:ignore // want "^unnecessary //exhaustive:ignore directive$"
+var _ = map[Direction]int{
+	N: 1,
+	E: 2,
+	S: 3,
+	W: 4,
+}
+
+//exhaustive:ignore
+var _ = map[Direction]int{
+	N: 1,
+}
+
+var (
+	//exhaustive:ignore ... keep this map short // want "^unnecessary //exhaustive:ignore directive$"
+	_ = map[Direction]int{N: 1, E: 2, S: 3, W: 4}
+)
+
+// The ignore comment applies to the nested map literals too, and is
+// necessary because the nested literal for key N is not exhaustive.
+//
+//exhaustive:ignore
+var _ = map[Direction]map[Direction]int{
+	N: map[Direction]int{N: 1},
+	E: map[Direction]int{N: 1, E: 2, S: 3, W: 4},
+	S: map[Direction]int{N: 1, E: 2, S: 3, W: 4},
+	W: map[Direction]int{N: 1, E: 2, S: 3, W: 4},
+}
+
+//exhaustive:ignore // want "^unnecessary //exhaustive:ignore directive$"
+var _ = map[Direction]map[Direction]int{
+	N: map[Direction]int{N: 1, E: 2, S: 3, W: 4},
+	E: map[Direction]int{N: 1, E: 2, S: 3, W: 4},
+	S: map[Direction]int{N: 1, E: 2, S: 3, W: 4},
+	W: map[Direction]int{N: 1, E: 2, S: 3, W: 4},
+}
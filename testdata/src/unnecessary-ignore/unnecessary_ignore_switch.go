@@ -0,0 +1,44 @@
+package unnecessaryignore
+
+func _a(d Direction) {
+	// All members are listed; the ignore comment is unnecessary.
+	//exhaustive:ignore // want "^unnecessary //exhaustive:ignore directive$"
+	switch d {
+	case N, E, S, W:
+	}
+
+	// Missing members; the ignore comment is necessary.
+	//exhaustive:ignore
+	switch d {
+	case N:
+	}
+}
+
+func _b(d Direction) {
+	// The nested switch statement is still checked.
+	//exhaustive:ignore
+	switch d {
+	case N:
+		switch d { // want "^missing cases in switch of type unnecessaryignore.Direction: unnecessaryignore.W$"
+		case N, E, S:
+		}
+	}
+}
+
+func _c(x int) {
+	// Not an enum switch statement; the ignore comment is left alone.
+	//exhaustive:ignore
+	switch x {
+	case 1:
+	}
+}
+
+func _d(d Direction) {
+	// The unknown group is not reported for an ignored switch statement,
+	// and makes the ignore comment necessary.
+	//exhaustive:ignore
+	//exhaustive:enforce group=finished
+	switch d { // want "^failed to parse directives: conflicting directives \"ignore\" and \"enforce\"$"
+	case N, E, S, W:
+	}
+}
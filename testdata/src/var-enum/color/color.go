@@ -0,0 +1,16 @@
+package color
+
+//exhaustive:enum
+type Color struct{ name string } // want Color:"^Red,Green,Blue$"
+
+var (
+	Red   = &Color{"red"}
+	Green = &Color{"green"}
+)
+
+var Blue = &Color{"blue"}
+
+// Not an enum member.
+//
+//exhaustive:ignore
+var favorite *Color = Red
@@ -0,0 +1,15 @@
+package errs
+
+import "errors"
+
+//exhaustive:enum
+var (
+	ErrNotFound = errors.New("not found") // want ErrNotFound:"^ErrNotFound,ErrTimeout,errInternal$"
+	ErrTimeout  = errors.New("timeout")   // want ErrTimeout:"^ErrNotFound,ErrTimeout,errInternal$"
+	errInternal = errors.New("internal")  // want errInternal:"^ErrNotFound,ErrTimeout,errInternal$"
+)
+
+// Not an enum.
+var (
+	ErrOther = errors.New("other")
+)
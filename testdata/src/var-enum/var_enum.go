@@ -0,0 +1,81 @@
+package varenum
+
+import (
+	"var-enum/color"
+	"var-enum/errs"
+)
+
+func _a(err error) {
+	switch err { // want "^missing cases in switch of type error: errs.ErrTimeout$"
+	case errs.ErrNotFound:
+	}
+
+	switch err {
+	case errs.ErrNotFound, errs.ErrTimeout:
+	case nil:
+	}
+
+	// Not an enum member.
+	switch err {
+	case errs.ErrOther:
+	}
+
+	if err == errs.ErrNotFound { // want "^missing cases in if-else chain of type error: errs.ErrTimeout$"
+	} else if err == errs.ErrOther {
+	}
+
+	switch { // want "^missing cases in switch of type error: errs.ErrTimeout$"
+	case err == errs.ErrNotFound:
+	}
+}
+
+func _b(c *color.Color) {
+	switch c { // want "^missing cases in switch of type color.Color: color.Green, color.Blue$"
+	case color.Red:
+	}
+
+	switch c {
+	case color.Red, color.Green, color.Blue:
+	}
+}
+
+type Shape int
+
+//exhaustive:enum
+var (
+	Square Shape = 1 // want Square:"^Square,Circle$"
+	Circle Shape = 2 // want Circle:"^Square,Circle$"
+)
+
+func _c(s Shape) {
+	switch s { // want "^missing cases in switch of type varenum.Shape: varenum.Circle$"
+	case Square:
+	}
+}
+
+type Level int // want Level:"^Low,High$"
+
+const (
+	Low Level = iota
+	High
+)
+
+var Default = High
+
+func _d(l Level) {
+	// A variable doesn't account for a member of an enum whose members
+	// are constants.
+	switch l { // want "^missing cases in switch of type varenum.Level: varenum.High$"
+	case Low, Default:
+	}
+
+	// Nor is a comparison against it an enum comparison.
+	if l == Low {
+	} else if l == Default {
+	}
+
+	switch {
+	case l == Low:
+	case l == Default:
+	}
+}
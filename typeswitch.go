@@ -0,0 +1,397 @@
+package exhaustive
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// sealedMembers is the set of types that implement a sealed interface.
+// The zero value is ready to use.
+type sealedMembers struct {
+	Names     []string             // implementing type names, in declaration order
+	NameToPtr map[string]bool      // type name -> whether only the pointer type implements the interface
+	NameToPos map[string]token.Pos // type name -> AST position
+}
+
+// add adds an implementing type to the set.
+func (sm *sealedMembers) add(name string, ptr bool, pos token.Pos) {
+	if sm.NameToPtr == nil {
+		sm.NameToPtr = make(map[string]bool)
+	}
+	if sm.NameToPos == nil {
+		sm.NameToPos = make(map[string]token.Pos)
+	}
+	sm.Names = append(sm.Names, name)
+	sm.NameToPtr[name] = ptr
+	sm.NameToPos[name] = pos
+}
+
+func (sm *sealedMembers) String() string {
+	return sm.factString()
+}
+
+func (sm *sealedMembers) factString() string {
+	var buf strings.Builder
+	for i, name := range sm.Names {
+		if sm.NameToPtr[name] {
+			buf.WriteString("*")
+		}
+		buf.WriteString(name)
+		if i != len(sm.Names)-1 {
+			buf.WriteString(",")
+		}
+	}
+	return buf.String()
+}
+
+// findSealedInterfaces finds the sealed interfaces declared in the
+// package, along with the types that implement them.
+//
+// An interface is sealed if it is declared at package scope and either
+// has an unexported method, which prevents types in other packages from
+// implementing it, or is associated with a "//exhaustive:sealed" comment.
+// The implementing types of a sealed interface are the non-interface
+// types declared at package scope in the same package that implement the
+// interface, either directly or through a pointer.
+func findSealedInterfaces(pkg *types.Package, inspect *inspector.Inspector, info *types.Info) map[*types.TypeName]sealedMembers {
+	var sealed []*types.TypeName
+	var candidates []*types.TypeName // in declaration order
+
+	inspect.Preorder([]ast.Node{&ast.GenDecl{}}, func(n ast.Node) {
+		gen := n.(*ast.GenDecl)
+		if gen.Tok != token.TYPE {
+			return
+		}
+
+		for _, s := range gen.Specs {
+			s := s.(*ast.TypeSpec)
+			tn, ok := info.Defs[s.Name].(*types.TypeName)
+			if !ok || tn.IsAlias() || tn.Parent() != pkg.Scope() {
+				continue
+			}
+			named, ok := tn.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() != 0 {
+				continue
+			}
+			intf, ok := named.Underlying().(*types.Interface)
+			if !ok {
+				candidates = append(candidates, tn)
+				continue
+			}
+			// Invalid directives, if any, are reported during enum
+			// discovery; see hasIgnoreDecl.
+			dirs, _ := parseDirectives([]*ast.CommentGroup{gen.Doc, s.Doc})
+			if dirs.has(ignoreDirective) {
+				continue
+			}
+			if isSealed(intf, dirs) {
+				sealed = append(sealed, tn)
+			}
+		}
+	})
+
+	result := make(map[*types.TypeName]sealedMembers)
+	for _, tn := range sealed {
+		intf := tn.Type().Underlying().(*types.Interface)
+		var sm sealedMembers
+		for _, c := range candidates {
+			switch {
+			case types.Implements(c.Type(), intf):
+				sm.add(c.Name(), false, c.Pos())
+			case types.Implements(types.NewPointer(c.Type()), intf):
+				sm.add(c.Name(), true, c.Pos())
+			}
+		}
+		if len(sm.Names) != 0 {
+			result[tn] = sm
+		}
+	}
+	return result
+}
+
+// isSealed reports whether the interface, whose declaration has the
+// supplied directives, is sealed.
+func isSealed(intf *types.Interface, dirs directiveSet) bool {
+	if !intf.IsMethodSet() || intf.NumMethods() == 0 {
+		// Constraint interfaces can't be used in type switches, and
+		// every type implements the empty interface.
+		return false
+	}
+	if dirs.has(sealedDirective) {
+		return true
+	}
+	for i := 0; i < intf.NumMethods(); i++ {
+		if !intf.Method(i).Exported() {
+			return true
+		}
+	}
+	return false
+}
+
+// sealedMember is a single implementing type of a sealed interface.
+type sealedMember struct {
+	obj *types.TypeName
+	ptr bool // whether only the pointer type implements the interface
+}
+
+// typ returns the type that implements the sealed interface.
+func (m sealedMember) typ() types.Type {
+	if m.ptr {
+		return types.NewPointer(m.obj.Type())
+	}
+	return m.obj.Type()
+}
+
+// typeSwitchConfig is configuration for typeSwitchChecker.
+type typeSwitchConfig struct {
+	explicit                   bool
+	defaultSignifiesExhaustive bool
+	checkGenerated             bool
+	ignoreType                 *regexp.Regexp     // can be nil
+	caseBody                   *template.Template // can be nil
+	oneofsOnly                 bool               // check only type switches over protobuf oneof interfaces
+}
+
+// typeSwitchChecker returns a node visitor that checks exhaustiveness of
+// type switch statements over sealed interfaces for the supplied pass,
+// and reports diagnostics. The node visitor expects only
+// *ast.TypeSwitchStmt nodes.
+func typeSwitchChecker(pass *analysis.Pass, cfg typeSwitchConfig, generated boolCache, comments commentCache) nodeVisitor {
+	return func(n ast.Node, push bool, stack []ast.Node) (bool, string) {
+		if !push {
+			return true, resultNotPush
+		}
+
+		file := stack[0].(*ast.File)
+
+		if !cfg.checkGenerated && generated.get(file) {
+			return false, resultGeneratedFile
+		}
+
+		sw := n.(*ast.TypeSwitchStmt)
+
+		directives, err := parseDirectives(comments.get(pass.Fset, file)[sw])
+		if err == nil {
+			err = directives.validateUse("a type switch statement", 0)
+		}
+		if err != nil {
+			pass.Report(makeInvalidDirectiveDiagnostic(sw, err))
+		}
+
+		if !cfg.explicit && directives.has(ignoreDirective) {
+			// Skip checking of this type switch statement due to ignore
+			// comment. Still return true because there may be nested
+			// switch statements that are not to be ignored.
+			return true, resultIgnoreComment
+		}
+		if cfg.explicit && !directives.has(enforceDirective) {
+			return true, resultNoEnforceComment
+		}
+
+		named, ok := pass.TypesInfo.TypeOf(typeSwitchOperand(sw)).(*types.Named)
+		if !ok {
+			return true, resultTagNotSealed
+		}
+		intf := named.Obj()
+		sm, ok := importSealedFact(pass, intf)
+		if !ok {
+			return true, resultTagNotSealed
+		}
+		if reMatch(cfg.ignoreType, fmt.Sprintf("%s.%s", intf.Pkg().Path(), intf.Name())) {
+			return true, resultTagNotSealed
+		}
+		if cfg.oneofsOnly && !isOneofInterface(intf, sm) {
+			return true, resultTagNotOneof
+		}
+
+		var checkl []sealedMember
+		for _, name := range sm.Names {
+			if !ast.IsExported(name) && intf.Pkg() != pass.Pkg {
+				continue
+			}
+			if reMatch(cfg.ignoreType, fmt.Sprintf("%s.%s", intf.Pkg().Path(), name)) {
+				continue
+			}
+			obj, ok := intf.Pkg().Scope().Lookup(name).(*types.TypeName)
+			if !ok {
+				continue
+			}
+			checkl = append(checkl, sealedMember{obj, sm.NameToPtr[name]})
+		}
+
+		missing, defaultCaseExists := analyzeTypeSwitchClauses(sw, pass.TypesInfo, checkl)
+		if len(missing) == 0 {
+			return true, resultEnumMembersAccounted
+		}
+		if defaultCaseExists && cfg.defaultSignifiesExhaustive {
+			return true, resultDefaultCaseSuffices
+		}
+		d := makeTypeSwitchDiagnostic(sw, intf, missing)
+		fix, err := makeTypeSwitchCasesFix(pass, file, sw, intf, missing, cfg.caseBody)
+		if err == nil {
+			d.SuggestedFixes = []analysis.SuggestedFix{fix}
+		}
+		pass.Report(d)
+		reportFixError(sw, err, pass.Report)
+		return true, resultReportedDiagnostic
+	}
+}
+
+// typeSwitchOperand returns the expression x in the type switch guard
+// x.(type) of the type switch statement.
+func typeSwitchOperand(sw *ast.TypeSwitchStmt) ast.Expr {
+	var e ast.Expr
+	switch s := sw.Assign.(type) {
+	case *ast.ExprStmt:
+		e = s.X // x.(type)
+	case *ast.AssignStmt:
+		e = s.Rhs[0] // v := x.(type)
+	}
+	return e.(*ast.TypeAssertExpr).X
+}
+
+// analyzeTypeSwitchClauses analyzes the clauses in the supplied type
+// switch statement. It returns the members in checkl that aren't listed
+// in the type switch statement, and whether the statement has a default
+// clause. A member is listed if a case clause lists its type, or an
+// interface type that it implements. The pointer to the type of a member
+// that implements the interface directly doesn't list the member, since
+// values of both types can occur.
+func analyzeTypeSwitchClauses(sw *ast.TypeSwitchStmt, info *types.Info, checkl []sealedMember) (missing []sealedMember, hasDefaultCase bool) {
+	var listed []types.Type
+	for _, stmt := range sw.Body.List {
+		caseCl := stmt.(*ast.CaseClause)
+		if isDefaultCase(caseCl) {
+			hasDefaultCase = true
+			continue
+		}
+		for _, expr := range caseCl.List {
+			tv, ok := info.Types[expr]
+			if !ok || !tv.IsType() {
+				continue // e.g. nil
+			}
+			listed = append(listed, tv.Type)
+		}
+	}
+
+	covers := func(t types.Type, m sealedMember) bool {
+		if types.Identical(t, m.typ()) {
+			return true
+		}
+		intf, ok := t.Underlying().(*types.Interface)
+		return ok && types.Implements(m.typ(), intf)
+	}
+
+	for _, m := range checkl {
+		found := false
+		for _, t := range listed {
+			if covers(t, m) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, m)
+		}
+	}
+	return missing, hasDefaultCase
+}
+
+func diagnosticSealedMember(m sealedMember) string {
+	s := m.obj.Pkg().Name() + "." + m.obj.Name()
+	if m.ptr {
+		return "*" + s
+	}
+	return s
+}
+
+func makeTypeSwitchDiagnostic(sw *ast.TypeSwitchStmt, intf *types.TypeName, missing []sealedMember) analysis.Diagnostic {
+	names := make([]string, len(missing))
+	for i := range missing {
+		names[i] = diagnosticSealedMember(missing[i])
+	}
+	return analysis.Diagnostic{
+		Pos:      sw.Pos(),
+		End:      sw.End(),
+		Category: CategoryMissingCases,
+		Message: fmt.Sprintf(
+			"missing cases in type switch of type %s: %s",
+			diagnosticEnumType(intf),
+			strings.Join(names, ", "),
+		),
+		Related: missingRelated(sw, names),
+	}
+}
+
+// makeTypeSwitchCasesFix returns a suggested fix that adds a case clause
+// for each of the missing members to the type switch statement. The body
+// of each case clause is the expansion of the body template, in which
+// .Tag refers to the variable declared by the type switch guard; if the
+// guard doesn't declare one and the template refers to .Tag, the fix
+// declares one. The error is non-nil if a fix cannot be made; see
+// errNoFix and unresolvedPackageError.
+func makeTypeSwitchCasesFix(pass *analysis.Pass, file *ast.File, sw *ast.TypeSwitchStmt, intf *types.TypeName, missing []sealedMember, body *template.Template) (analysis.SuggestedFix, error) {
+	typ, ok := typeExpr(file, pass.Pkg, intf.Type())
+	if !ok {
+		return analysis.SuggestedFix{}, errNoFix
+	}
+
+	var tag, bind string
+	if assign, ok := sw.Assign.(*ast.AssignStmt); ok {
+		tag = assign.Lhs[0].(*ast.Ident).Name
+	} else {
+		bind = unusedName(pass.Pkg, sw.Body.Lbrace, "v")
+		tag = bind
+	}
+
+	var buf strings.Builder
+	var allStmts []string
+	var usedTag bool
+	insertPos, prefix, indent := caseClauseInsertion(pass.Fset, sw, sw.Body)
+	buf.WriteString(prefix)
+
+	for _, m := range missing {
+		expr, ok := typeExpr(file, pass.Pkg, m.typ())
+		if !ok {
+			return analysis.SuggestedFix{}, errNoFix
+		}
+		data := fixData{Type: typ, Member: expr, tag: tag}
+		stmts, err := executeFixTemplate(body, &data)
+		if err != nil {
+			return analysis.SuggestedFix{}, errNoFix
+		}
+		usedTag = usedTag || data.usedTag
+		allStmts = append(allStmts, stmts...)
+
+		buf.WriteString("case " + expr + ":\n")
+		writeStmts(&buf, stmts, indent+"\t")
+		buf.WriteString(indent)
+	}
+
+	var edits []analysis.TextEdit
+	if usedTag && bind != "" {
+		pos := sw.Assign.Pos()
+		edits = append(edits, analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(bind + " := ")})
+	}
+	imports, err := importEdits(pass, file, sw.Pos(), allStmts, bind)
+	if err != nil {
+		return analysis.SuggestedFix{}, err
+	}
+	edits = append(edits, imports...)
+	return analysis.SuggestedFix{
+		Message: "add missing cases",
+		TextEdits: append(edits, analysis.TextEdit{
+			Pos:     insertPos,
+			End:     insertPos,
+			NewText: []byte(buf.String()),
+		}),
+	}, nil
+}
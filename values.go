@@ -0,0 +1,90 @@
+package exhaustive
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// valueExprs returns the expressions, among exprs, that have a constant
+// value but are not considered valid to satisfy exhaustiveness by
+// exprConstVal, such as the literal 3 or the expression A + 1.
+func valueExprs(exprs []ast.Expr, info *types.Info) []ast.Expr {
+	var out []ast.Expr
+	for _, e := range exprs {
+		if _, ok := exprConstVal(e, info); ok {
+			continue
+		}
+		if tv, ok := info.Types[e]; ok && tv.Value != nil {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// analyzeValueExprs calls each for the constant value of each of the
+// expressions returned by valueExprs. With the -match-values flag, such
+// expressions satisfy exhaustiveness for the members with the same
+// value.
+func analyzeValueExprs(exprs []ast.Expr, info *types.Info, each func(constantValue)) {
+	for _, e := range valueExprs(exprs, info) {
+		each(constantValue(info.Types[e].Value.ExactString()))
+	}
+}
+
+// reportValueExprs reports each of the expressions returned by valueExprs
+// whose value is the value of an enum member, with a suggested fix that
+// replaces the expression with the member's name.
+func reportValueExprs(pass *analysis.Pass, file *ast.File, exprs []ast.Expr, es []enumTypeAndMembers, report func(analysis.Diagnostic)) {
+	for _, e := range valueExprs(exprs, pass.TypesInfo) {
+		val := constantValue(pass.TypesInfo.Types[e].Value.ExactString())
+		if m, ok := memberWithValue(pass.Pkg, es, val); ok {
+			report(makeValueExprDiagnostic(pass, file, e, m))
+		}
+	}
+}
+
+// memberWithValue returns the first declared member, among the members
+// of the enums that can be referred to from the package, whose value is
+// val.
+func memberWithValue(from *types.Package, es []enumTypeAndMembers, val constantValue) (member, bool) {
+	for _, e := range es {
+		for _, name := range e.members.ValueToNames[val] {
+			if isBlankIdentifier(name) || (!ast.IsExported(name) && e.typ.Pkg() != from) {
+				continue
+			}
+			return member{e.members.NameToPos[name], e.typ, name, val}, true
+		}
+	}
+	return member{}, false
+}
+
+func makeValueExprDiagnostic(pass *analysis.Pass, file *ast.File, e ast.Expr, m member) analysis.Diagnostic {
+	d := analysis.Diagnostic{
+		Pos:      e.Pos(),
+		End:      e.End(),
+		Category: CategoryMemberValue,
+		Message: fmt.Sprintf(
+			"%s has the value of enum member %s",
+			types.ExprString(e),
+			diagnosticMember(m),
+		),
+	}
+	if _, ok := pass.TypesInfo.TypeOf(e).(*types.TypeParam); ok {
+		// The member would have to be converted to the type parameter.
+		return d
+	}
+	if expr, ok := memberExpr(file, pass.Pkg, m); ok {
+		d.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: fmt.Sprintf("replace with %s", expr),
+			TextEdits: []analysis.TextEdit{{
+				Pos:     e.Pos(),
+				End:     e.End(),
+				NewText: []byte(expr),
+			}},
+		}}
+	}
+	return d
+}
@@ -0,0 +1,194 @@
+package exhaustive
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// A variable enum is an enum whose members are package-level variables
+// rather than constants. Variable enums are declared using the
+// "//exhaustive:enum" directive, in one of two ways:
+//
+//   - On a type declaration: the members are the package-level variables
+//     of the type, or of the pointer to the type, in the package, except
+//     for those declared with an "//exhaustive:ignore" comment.
+//   - On a var declaration: the members are the variables declared by it,
+//     whatever their type.
+//
+// The members of a variable enum are recorded in the same enumMembers
+// structure as constants, using varValue as the value of each member, so
+// that listing a member in a case clause accounts for it by identity.
+
+// varEnumBlock is a variable enum declared on a var declaration.
+type varEnumBlock struct {
+	vars    []*types.Var
+	members enumMembers
+}
+
+// varValue returns the value that represents the package-level variable
+// v in enumMembers. It cannot collide with a constant value.
+func varValue(v *types.Var) constantValue {
+	return constantValue("&" + v.Pkg().Path() + "." + v.Name())
+}
+
+// isVarEnum reports whether the members are those of a variable enum.
+func (em *enumMembers) isVarEnum() bool {
+	return len(em.Names) != 0 && strings.HasPrefix(string(em.NameToValue[em.Names[0]]), "&")
+}
+
+// hasVarEnum reports whether any of the enums is a variable enum.
+func hasVarEnum(es []enumTypeAndMembers) bool {
+	for _, e := range es {
+		if e.members.isVarEnum() {
+			return true
+		}
+	}
+	return false
+}
+
+// exprMemberVal is like exprConstVal, but if vars is true, an expression
+// that refers to a package-level variable is considered to have the value
+// varValue, so that it can satisfy exhaustiveness for variable enums.
+// The vars parameter should be true only when checking against a
+// variable enum.
+func exprMemberVal(e ast.Expr, info *types.Info, vars bool) (constantValue, bool) {
+	if vars {
+		if v := referencedPackageVar(e, info); v != nil {
+			return varValue(v), true
+		}
+	}
+	return exprConstVal(e, info)
+}
+
+// isPackageVar reports whether v is a package-level variable.
+func isPackageVar(v *types.Var) bool {
+	return v.Pkg() != nil && v.Parent() == v.Pkg().Scope()
+}
+
+// findVarEnums finds the variable enums declared in the package. Types
+// that are enum types by virtue of their constants, as found by
+// findEnums, are not variable enum types.
+func findVarEnums(pkg *types.Package, inspect *inspector.Inspector, info *types.Info, constEnums map[enumType]enumMembers) (typeEnums map[enumType]enumMembers, blockEnums []varEnumBlock) {
+	var annotated []*types.TypeName
+	var vars []*types.Var // package-level variables, in declaration order
+
+	inspect.Preorder([]ast.Node{&ast.GenDecl{}}, func(n ast.Node) {
+		gen := n.(*ast.GenDecl)
+		switch gen.Tok {
+		case token.TYPE:
+			for _, s := range gen.Specs {
+				s := s.(*ast.TypeSpec)
+				// Invalid directives, if any, are reported during enum
+				// discovery; see hasIgnoreDecl.
+				dirs, _ := parseDirectives([]*ast.CommentGroup{gen.Doc, s.Doc})
+				if !dirs.has(enumDirective) || dirs.has(ignoreDirective) {
+					continue
+				}
+				tn, ok := info.Defs[s.Name].(*types.TypeName)
+				if !ok || tn.IsAlias() || tn.Parent() != pkg.Scope() {
+					continue
+				}
+				if _, ok := constEnums[enumType{tn}]; ok {
+					continue
+				}
+				annotated = append(annotated, tn)
+			}
+
+		case token.VAR:
+			dirs, _ := parseDirectives([]*ast.CommentGroup{gen.Doc})
+			if dirs.has(ignoreDirective) {
+				return
+			}
+			var block varEnumBlock
+			for _, s := range gen.Specs {
+				s := s.(*ast.ValueSpec)
+				if specDirs, _ := parseDirectives([]*ast.CommentGroup{s.Doc}); specDirs.has(ignoreDirective) {
+					continue
+				}
+				for _, name := range s.Names {
+					v, ok := info.Defs[name].(*types.Var)
+					if !ok || isBlankIdentifier(v.Name()) || !isPackageVar(v) {
+						continue
+					}
+					vars = append(vars, v)
+					block.vars = append(block.vars, v)
+					block.members.add(v.Name(), varValue(v), v.Pos())
+				}
+			}
+			if dirs.has(enumDirective) && len(block.vars) != 0 {
+				blockEnums = append(blockEnums, block)
+			}
+		}
+	})
+
+	typeEnums = make(map[enumType]enumMembers)
+	for _, tn := range annotated {
+		var em enumMembers
+		for _, v := range vars {
+			if types.Identical(v.Type(), tn.Type()) || types.Identical(v.Type(), types.NewPointer(tn.Type())) {
+				em.add(v.Name(), varValue(v), v.Pos())
+			}
+		}
+		if len(em.Names) != 0 {
+			typeEnums[enumType{tn}] = em
+		}
+	}
+	return typeEnums, blockEnums
+}
+
+// referencedPackageVar returns the package-level variable that the
+// expression, of the form v or pkg.V, refers to, or nil.
+func referencedPackageVar(e ast.Expr, info *types.Info) *types.Var {
+	var ident *ast.Ident
+	switch e := astutil.Unparen(e).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		x, ok := astutil.Unparen(e.X).(*ast.Ident)
+		if !ok || !denotesPackage(x, info) {
+			return nil
+		}
+		ident = e.Sel
+	default:
+		return nil
+	}
+	v, ok := info.Uses[ident].(*types.Var)
+	if !ok || !isPackageVar(v) {
+		return nil
+	}
+	return v
+}
+
+// varEnumsReferenced returns the variable enums, declared on var
+// declarations, that have members the expressions refer to. The enum
+// type of such an enum is a type name, not declared in any scope, whose
+// type is the type of the enum's first member; see diagnosticEnumType.
+func varEnumsReferenced(pass *analysis.Pass, exprs []ast.Expr) []enumTypeAndMembers {
+	var result []enumTypeAndMembers
+	seen := make(map[*types.Var]bool) // first members of the enums in result
+	for _, e := range exprs {
+		v := referencedPackageVar(e, pass.TypesInfo)
+		if v == nil {
+			continue
+		}
+		em, ok := importVarFact(pass, v)
+		if !ok {
+			continue
+		}
+		first, ok := v.Pkg().Scope().Lookup(em.Names[0]).(*types.Var)
+		if !ok || seen[first] {
+			continue
+		}
+		seen[first] = true
+		name := types.TypeString(first.Type(), types.RelativeTo(first.Pkg()))
+		tn := types.NewTypeName(first.Pos(), first.Pkg(), name, first.Type())
+		result = append(result, enumTypeAndMembers{enumType{tn}, em})
+	}
+	return result
+}